@@ -0,0 +1,124 @@
+package gomod
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// PseudoVersionTimestampFormat is the time format of the timestamp in pseudo-versions.
+const PseudoVersionTimestampFormat = "20060102150405"
+
+// ref. https://github.com/golang/mod/blob/master/module/pseudo.go
+var pseudoVersionRegexp = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// pseudo holds the fields of a pseudo-version.
+// e.g. v1.2.4-0.20231012003039-104605ab7028 => base: v1.2.4-0, timestamp: 20231012003039, revision: 104605ab7028
+type pseudo struct {
+	base      string
+	timestamp string
+	revision  string
+}
+
+// parsePseudo returns the fields of v, or nil if v is not a pseudo-version.
+// It works like golang.org/x/mod/module.IsPseudoVersion.
+func parsePseudo(v string) *pseudo {
+	if strings.Count(v, "-") < 2 || !pseudoVersionRegexp.MatchString(v) {
+		return nil
+	}
+
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+
+	p := new(pseudo)
+	j := strings.LastIndexByte(v, '-')
+	v, p.revision = v[:j], v[j+1:]
+	i := strings.LastIndexByte(v, '-')
+	if j := strings.LastIndexByte(v, '.'); j > i {
+		// vX.Y.Z-pre.0.yyyymmddhhmmss or vX.Y.(Z+1)-0.yyyymmddhhmmss
+		p.base, p.timestamp = v[:j], v[j+1:]
+	} else {
+		// vX.0.0-yyyymmddhhmmss
+		p.base, p.timestamp = v[:i], v[i+1:]
+	}
+	return p
+}
+
+// IsPseudo returns true if the version is a pseudo-version such as v0.0.0-20231012003039-104605ab7028.
+func (v Version) IsPseudo() bool {
+	return v.pseudo != nil
+}
+
+// PseudoTime returns the timestamp of the pseudo-version.
+// It returns an error if the version is not a pseudo-version or the timestamp is not a valid time.
+func (v Version) PseudoTime() (time.Time, error) {
+	if v.pseudo == nil {
+		return time.Time{}, xerrors.Errorf("%s is not a pseudo-version", v.original)
+	}
+
+	t, err := time.Parse(PseudoVersionTimestampFormat, v.pseudo.timestamp)
+	if err != nil {
+		return time.Time{}, xerrors.Errorf("malformed time %q: %w", v.pseudo.timestamp, err)
+	}
+	return t, nil
+}
+
+// PseudoRevision returns the revision identifier of the pseudo-version, usually a 12-character commit hash prefix.
+// It returns an empty string if the version is not a pseudo-version.
+func (v Version) PseudoRevision() string {
+	if v.pseudo == nil {
+		return ""
+	}
+	return v.pseudo.revision
+}
+
+// PseudoBase returns the tagged version upon which the pseudo-version is based
+// like golang.org/x/mod/module.PseudoVersionBase.
+// e.g. v1.2.4-0.20231012003039-104605ab7028 => v1.2.3, v1.2.3-pre.0.20231012003039-104605ab7028 => v1.2.3-pre
+// It returns false if the pseudo-version has no base version, i.e. vX.0.0-yyyymmddhhmmss-abcdef123456,
+// and an error if the version is not a pseudo-version or the base version would be invalid.
+func (v Version) PseudoBase() (Version, bool, error) {
+	if v.pseudo == nil {
+		return Version{}, false, xerrors.Errorf("%s is not a pseudo-version", v.original)
+	}
+
+	var build string
+	if metadata := v.version.Metadata(); metadata != "" {
+		build = "+" + metadata
+	}
+
+	base := v.pseudo.base
+	i := strings.IndexByte(base, '-')
+	switch {
+	case i < 0:
+		// vX.0.0-yyyymmddhhmmss-abcdef123456
+		if build != "" {
+			return Version{}, false, xerrors.Errorf("%s lacks base version, but has build metadata %q", v.original, build)
+		}
+		return Version{}, false, nil
+	case base[i:] == "-0":
+		// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdef123456 => vX.Y.Z
+		release := base[:i]
+		j := strings.LastIndexByte(release, '.')
+		patch, err := strconv.ParseUint(release[j+1:], 10, 64)
+		if err != nil {
+			return Version{}, false, xerrors.Errorf("invalid patch version: %w", err)
+		} else if patch == 0 {
+			return Version{}, false, xerrors.Errorf("version before %s would have negative patch number", base)
+		}
+		base = release[:j+1] + strconv.FormatUint(patch-1, 10)
+	default:
+		// vX.Y.Z-pre.0.yyyymmddhhmmss-abcdef123456 => vX.Y.Z-pre
+		base = strings.TrimSuffix(base, ".0")
+	}
+
+	b, err := Parse(base + build)
+	if err != nil {
+		return Version{}, false, err
+	}
+	return b, true, nil
+}
@@ -0,0 +1,125 @@
+package gomod_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/gomod"
+)
+
+func TestVersion_IsPseudo(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "v0.0.0-20231012003039-104605ab7028", want: true},
+		{version: "v1.2.4-0.20231012003039-104605ab7028", want: true},
+		{version: "v1.2.3-pre.0.20231012003039-104605ab7028", want: true},
+		{version: "v2.3.5-0.20231012003039-104605ab7028+incompatible", want: true},
+		{version: "v1.2.0-0.20231012003039-104605ab7028", want: true},
+		{version: "v1.2.3", want: false},
+		{version: "v1.2.4-0", want: false},
+		{version: "v1.2.3-pre.20231012003039-104605ab7028", want: false},
+		{version: "v1.0.0-2023101200303-104605ab7028", want: false},
+		{version: "v1.2.3-20231012003039-104605ab7028", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, gomod.MustParse(tt.version).IsPseudo())
+		})
+	}
+}
+
+func TestVersion_PseudoBase(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantOK  bool
+		wantErr bool
+	}{
+		{version: "v0.0.0-20231012003039-104605ab7028"},
+		{version: "v1.2.4-0.20231012003039-104605ab7028", want: "v1.2.3", wantOK: true},
+		{version: "v1.2.10-0.20231012003039-104605ab7028", want: "v1.2.9", wantOK: true},
+		{version: "v1.2.3-pre.0.20231012003039-104605ab7028", want: "v1.2.3-pre", wantOK: true},
+		{version: "v1.2.3-0.0.20231012003039-104605ab7028", want: "v1.2.3-0", wantOK: true},
+		{version: "v2.3.5-0.20231012003039-104605ab7028+incompatible", want: "v2.3.4+incompatible", wantOK: true},
+		{version: "v2.0.0-20231012003039-104605ab7028+incompatible", wantErr: true},
+		{version: "v1.2.0-0.20231012003039-104605ab7028", wantErr: true},
+		{version: "v1.2.3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, ok, err := gomod.MustParse(tt.version).PseudoBase()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestVersion_PseudoBaseXMod(t *testing.T) {
+	// The cases of golang.org/x/mod/module/pseudo_test.go
+	tests := []struct {
+		older   string
+		version string
+	}{
+		{older: "", version: "v0.0.0-20060102150405-hash"},
+		{older: "", version: "v1.0.0-20060102150405-hash"},
+		{older: "", version: "v2.0.0-20060102150405-hash"},
+		{older: "v0.0.0", version: "v0.0.1-0.20060102150405-hash"},
+		{older: "v1.2.3", version: "v1.2.4-0.20060102150405-hash"},
+		{older: "v1.2.99999999999999999", version: "v1.2.100000000000000000-0.20060102150405-hash"},
+		{older: "v1.2.3-pre", version: "v1.2.3-pre.0.20060102150405-hash"},
+		{older: "v1.3.0-pre", version: "v1.3.0-pre.0.20060102150405-hash"},
+		{older: "v0.0.0--", version: "v0.0.0--.0.20060102150405-hash"},
+		{older: "v1.0.0+metadata", version: "v1.0.1-0.20060102150405-hash+metadata"},
+		{older: "v2.0.0+incompatible", version: "v2.0.1-0.20060102150405-hash+incompatible"},
+		{older: "v2.3.0-pre+incompatible", version: "v2.3.0-pre.0.20060102150405-hash+incompatible"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := gomod.MustParse(tt.version)
+			require.True(t, v.IsPseudo())
+
+			got, err := v.PseudoTime()
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), got)
+			assert.Equal(t, "hash", v.PseudoRevision())
+
+			base, ok, err := v.PseudoBase()
+			require.NoError(t, err)
+			assert.Equal(t, tt.older != "", ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.older, base.String())
+
+			// A pseudo-version is greater than its base version
+			assert.True(t, v.GreaterThan(gomod.MustParse(tt.older)))
+		})
+	}
+}
+
+func TestVersion_PseudoTime(t *testing.T) {
+	v := gomod.MustParse("v1.2.4-0.20231012003039-104605ab7028")
+	got, err := v.PseudoTime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 10, 12, 0, 30, 39, 0, time.UTC), got)
+	assert.Equal(t, "104605ab7028", v.PseudoRevision())
+
+	_, err = gomod.MustParse("v1.2.4-0.20231099003039-104605ab7028").PseudoTime()
+	assert.Error(t, err)
+
+	_, err = gomod.MustParse("v1.2.3").PseudoTime()
+	assert.Error(t, err)
+	assert.Empty(t, gomod.MustParse("v1.2.3").PseudoRevision())
+}
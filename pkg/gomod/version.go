@@ -0,0 +1,167 @@
+package gomod
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+const incompatible = "incompatible"
+
+// Version represents a Go module version such as v1.2.3, v2.3.4+incompatible
+// and the pseudo-version v0.0.0-20231012003039-104605ab7028.
+// It is compared in the same way as golang.org/x/mod/semver.
+type Version struct {
+	version  semver.Version
+	pseudo   *pseudo
+	original string
+}
+
+// Parse parses a given Go module version.
+// The "v" prefix is required, and vMAJOR and vMAJOR.MINOR are accepted
+// as shorthands for vMAJOR.0.0 and vMAJOR.MINOR.0 like golang.org/x/mod/semver.
+// Unlike golang.org/x/mod/semver, numbers larger than uint64 are rejected,
+// including numeric pre-release identifiers, since they couldn't be compared numerically.
+func Parse(v string) (Version, error) {
+	if v == "" || v[0] != 'v' {
+		return Version{}, &semver.ParseError{
+			Input:     v,
+			Component: "prefix",
+			Reason:    `missing "v" prefix`,
+		}
+	}
+
+	s := v
+	switch strings.Count(v, ".") {
+	case 0:
+		if isNumber(v[1:]) {
+			s += ".0.0"
+		}
+	case 1:
+		if major, minor, _ := strings.Cut(v[1:], "."); isNumber(major) && isNumber(minor) {
+			s += ".0"
+		}
+	}
+
+	sv, err := semver.Parse(s, semver.WithVPrefix(true))
+	if err != nil {
+		return Version{}, err
+	}
+	if err = validatePreRelease(v); err != nil {
+		return Version{}, err
+	}
+
+	return Version{
+		version:  sv,
+		pseudo:   parsePseudo(v),
+		original: v,
+	}, nil
+}
+
+// MustParse works like Parse, but panics if the version cannot be parsed.
+func MustParse(v string) Version {
+	ver, err := Parse(v)
+	if err != nil {
+		panic(err)
+	}
+	return ver
+}
+
+// SemVer returns the version as a semantic version.
+func (v Version) SemVer() semver.Version {
+	return v.version
+}
+
+// Incompatible returns true if the version has the +incompatible suffix,
+// which is used for major versions 2 and above of modules without go.mod or "/vN" suffix.
+func (v Version) Incompatible() bool {
+	return v.version.Metadata() == incompatible
+}
+
+// Compare compares this version to another one. It returns -1, 0, or 1 if
+// the version smaller, equal, or larger than the other version.
+// Build metadata including +incompatible is ignored as well as golang.org/x/mod/semver.
+func (v Version) Compare(o Version) int {
+	return v.version.Compare(o.version)
+}
+
+// Equal tests if two versions are equal to each other.
+func (v Version) Equal(o Version) bool {
+	return v.Compare(o) == 0
+}
+
+// LessThan tests if one version is less than another one.
+func (v Version) LessThan(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+// GreaterThan tests if one version is greater than another one.
+func (v Version) GreaterThan(o Version) bool {
+	return v.Compare(o) > 0
+}
+
+// String returns the version with the "v" prefix and build metadata.
+// Shorthands are expanded, e.g. v1.2 => v1.2.0
+func (v Version) String() string {
+	return "v" + v.version.String()
+}
+
+// Canonical returns the canonical form of the version like golang.org/x/mod/module.CanonicalVersion.
+// Build metadata is discarded except for +incompatible, which is significant in go.mod.
+// e.g. v1.2 => v1.2.0, v2.3.4+incompatible => v2.3.4+incompatible, v1.2.3+meta => v1.2.3
+func (v Version) Canonical() string {
+	s := "v" + v.version.Canonical()
+	if v.Incompatible() {
+		s += "+" + incompatible
+	}
+	return s
+}
+
+// Original returns the original value.
+func (v Version) Original() string {
+	return v.original
+}
+
+// validatePreRelease returns an error if a numeric pre-release identifier is larger than uint64.
+// semver.Parse keeps such an identifier as a string, which is compared lexically
+// while golang.org/x/mod/semver compares it numerically.
+func validatePreRelease(v string) error {
+	start := strings.IndexByte(v, '-') + 1
+	if start == 0 {
+		return nil
+	}
+	end := len(v)
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		end = i
+	}
+
+	for offset := start; offset <= end; {
+		id, _, _ := strings.Cut(v[offset:end], ".")
+		if isNumber(id) {
+			if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+				return &semver.ParseError{
+					Input:     v,
+					Offset:    offset,
+					Component: "prerelease",
+					Reason:    "invalid prerelease identifier",
+					Err:       err,
+				}
+			}
+		}
+		offset += len(id) + 1
+	}
+	return nil
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
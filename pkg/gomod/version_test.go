@@ -0,0 +1,165 @@
+package gomod_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/gomod"
+	"github.com/aquasecurity/go-version/pkg/semver"
+	"github.com/aquasecurity/go-version/pkg/versions"
+)
+
+var _ versions.Comparable[gomod.Version] = gomod.Version{}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		version       string
+		wantString    string
+		wantCanonical string
+		wantErr       bool
+	}{
+		{version: "v1.2.3", wantString: "v1.2.3", wantCanonical: "v1.2.3"},
+		{version: "v1", wantString: "v1.0.0", wantCanonical: "v1.0.0"},
+		{version: "v1.2", wantString: "v1.2.0", wantCanonical: "v1.2.0"},
+		{version: "v1.2.3-pre+meta", wantString: "v1.2.3-pre+meta", wantCanonical: "v1.2.3-pre"},
+		{version: "v2.3.4+incompatible", wantString: "v2.3.4+incompatible", wantCanonical: "v2.3.4+incompatible"},
+		{
+			version:       "v0.0.0-20231012003039-104605ab7028",
+			wantString:    "v0.0.0-20231012003039-104605ab7028",
+			wantCanonical: "v0.0.0-20231012003039-104605ab7028",
+		},
+		{version: "1.2.3", wantErr: true},
+		{version: "v01.2.3", wantErr: true},
+		{version: "v1.2-pre", wantErr: true},
+		{version: "v1.2.3.4", wantErr: true},
+		{version: "v1.0.0-100000000000000000000", wantErr: true},
+		{version: "v1.0.0-rc.18446744073709551616+meta", wantErr: true},
+		{version: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := gomod.Parse(tt.version)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, semver.ErrInvalidSemVer)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantString, v.String())
+			assert.Equal(t, tt.wantCanonical, v.Canonical())
+			assert.Equal(t, tt.version, v.Original())
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// Ascending order by golang.org/x/mod/semver.Compare
+	sorted := []string{
+		"v0.0.0-20231012003039-104605ab7028",
+		"v0.0.0",
+		"v1.2.3-pre",
+		"v1.2.3-pre.0.20231012003039-104605ab7028",
+		"v1.2.3",
+		"v1.2.4-0.20231012003039-104605ab7028",
+		"v1.2.4-0.20231099003039-104605ab7028",
+		"v1.2.4-1",
+		"v1.2.4-rc.1",
+		"v2.0.0-20231012003039-104605ab7028+incompatible",
+		"v2.3.4+incompatible",
+		"v2.3.5-0.20231012003039-104605ab7028+incompatible",
+	}
+	for i := range sorted {
+		for j := range sorted {
+			v1, v2 := gomod.MustParse(sorted[i]), gomod.MustParse(sorted[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			assert.Equal(t, want, v1.Compare(v2), "%s vs %s", sorted[i], sorted[j])
+		}
+	}
+
+	// Build metadata including +incompatible is ignored, and shorthands are expanded
+	assert.True(t, gomod.MustParse("v2.3.4+incompatible").Equal(gomod.MustParse("v2.3.4")))
+	assert.True(t, gomod.MustParse("v1.2").Equal(gomod.MustParse("v1.2.0+meta")))
+	assert.True(t, gomod.MustParse("v1.2.3").LessThan(gomod.MustParse("v1.10.0")))
+	assert.True(t, gomod.MustParse("v1.0.0-beta.11").GreaterThan(gomod.MustParse("v1.0.0-beta.2")))
+}
+
+// The cases of golang.org/x/mod/semver/semver_test.go.
+// A valid version has its canonical form, and the valid versions are in ascending order.
+var xmodSemverTests = []struct {
+	in, out string
+}{
+	{"bad", ""},
+	{"v1-alpha.beta.gamma", ""},
+	{"v1-pre", ""},
+	{"v1+meta", ""},
+	{"v1-pre+meta", ""},
+	{"v1.2-pre", ""},
+	{"v1.2+meta", ""},
+	{"v1.2-pre+meta", ""},
+	{"v1.0.0-alpha", "v1.0.0-alpha"},
+	{"v1.0.0-alpha.1", "v1.0.0-alpha.1"},
+	{"v1.0.0-alpha.beta", "v1.0.0-alpha.beta"},
+	{"v1.0.0-beta", "v1.0.0-beta"},
+	{"v1.0.0-beta.2", "v1.0.0-beta.2"},
+	{"v1.0.0-beta.11", "v1.0.0-beta.11"},
+	{"v1.0.0-rc.1", "v1.0.0-rc.1"},
+	{"v1", "v1.0.0"},
+	{"v1.0", "v1.0.0"},
+	{"v1.0.0", "v1.0.0"},
+	{"v1.2", "v1.2.0"},
+	{"v1.2.0", "v1.2.0"},
+	{"v1.2.3-456", "v1.2.3-456"},
+	{"v1.2.3-456.789", "v1.2.3-456.789"},
+	{"v1.2.3-456-789", "v1.2.3-456-789"},
+	{"v1.2.3-456a", "v1.2.3-456a"},
+	{"v1.2.3-pre", "v1.2.3-pre"},
+	{"v1.2.3-pre+meta", "v1.2.3-pre"},
+	{"v1.2.3-pre.1", "v1.2.3-pre.1"},
+	{"v1.2.3-zzz", "v1.2.3-zzz"},
+	{"v1.2.3", "v1.2.3"},
+	{"v1.2.3+meta", "v1.2.3"},
+	{"v1.2.3+meta-pre", "v1.2.3"},
+	{"v1.2.3+meta-pre.sha.256a", "v1.2.3"},
+}
+
+func TestVersion_CompareXMod(t *testing.T) {
+	var valid []string
+	for _, tt := range xmodSemverTests {
+		v, err := gomod.Parse(tt.in)
+		if tt.out == "" {
+			assert.Error(t, err, tt.in)
+			continue
+		}
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.out, v.Canonical(), tt.in)
+		valid = append(valid, tt.in)
+	}
+
+	canonical := func(s string) string { return gomod.MustParse(s).Canonical() }
+	for i, s1 := range valid {
+		for j, s2 := range valid {
+			want := 0
+			switch {
+			case canonical(s1) == canonical(s2):
+			case i < j:
+				want = -1
+			default:
+				want = 1
+			}
+			assert.Equal(t, want, gomod.MustParse(s1).Compare(gomod.MustParse(s2)), "%s vs %s", s1, s2)
+		}
+	}
+}
+
+func TestVersion_Incompatible(t *testing.T) {
+	assert.True(t, gomod.MustParse("v2.3.4+incompatible").Incompatible())
+	assert.True(t, gomod.MustParse("v2.3.5-0.20231012003039-104605ab7028+incompatible").Incompatible())
+	assert.False(t, gomod.MustParse("v2.3.4").Incompatible())
+	assert.False(t, gomod.MustParse("v2.3.4+meta").Incompatible())
+}
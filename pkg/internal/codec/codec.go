@@ -0,0 +1,66 @@
+// Package codec provides the binary encoding and the SQL scanning shared by the semver and version packages.
+package codec
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/xerrors"
+)
+
+// binaryFormat is the first byte of the MarshalBinary output.
+const binaryFormat byte = 1
+
+// MarshalBinary encodes a flag byte and strings after binaryFormat.
+// Each string is prefixed with its length as a uvarint.
+func MarshalBinary(flag byte, ss ...string) []byte {
+	size := 2
+	for _, s := range ss {
+		size += binary.MaxVarintLen64 + len(s)
+	}
+
+	b := make([]byte, 0, size)
+	b = append(b, binaryFormat, flag)
+	for _, s := range ss {
+		b = binary.AppendUvarint(b, uint64(len(s)))
+		b = append(b, s...)
+	}
+	return b
+}
+
+// UnmarshalBinary decodes the flag byte and n strings encoded by MarshalBinary.
+func UnmarshalBinary(data []byte, n int) (byte, []string, error) {
+	if len(data) == 0 || data[0] != binaryFormat {
+		return 0, nil, xerrors.New("unknown binary version format")
+	} else if len(data) < 2 {
+		return 0, nil, xerrors.New("truncated binary version")
+	}
+	flag, data := data[1], data[2:]
+
+	ss := make([]string, n)
+	for i := range ss {
+		l, size := binary.Uvarint(data)
+		if size <= 0 || l > uint64(len(data)-size) {
+			return 0, nil, xerrors.New("truncated binary version")
+		}
+		data = data[size:]
+		ss[i], data = string(data[:l]), data[l:]
+	}
+	if len(data) != 0 {
+		return 0, nil, xerrors.New("trailing data after binary version")
+	}
+	return flag, ss, nil
+}
+
+// ScanString returns a string or []byte column as a string.
+func ScanString(src any) (string, error) {
+	switch s := src.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	case nil:
+		return "", xerrors.New("NULL value")
+	default:
+		return "", xerrors.Errorf("unsupported type %T", src)
+	}
+}
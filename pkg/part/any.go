@@ -7,12 +7,16 @@ import (
 type Any bool
 
 func NewAny(s string) (Any, error) {
-	if s == "*" || s == "x" || s == "X" {
+	if isAny(s) {
 		return true, nil
 	}
 	return true, xerrors.New("not wildcard")
 }
 
+func isAny(s string) bool {
+	return s == "*" || s == "x" || s == "X"
+}
+
 func (s Any) Compare(other Part) int {
 	if s {
 		return 0
@@ -31,3 +35,10 @@ func (s Any) IsAny() bool {
 func (s Any) IsEmpty() bool {
 	return false
 }
+
+func (s Any) String() string {
+	if s {
+		return "*"
+	}
+	return ""
+}
@@ -26,3 +26,7 @@ func (s Empty) IsAny() bool {
 func (s Empty) IsEmpty() bool {
 	return true
 }
+
+func (s Empty) String() string {
+	return ""
+}
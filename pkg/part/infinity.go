@@ -25,6 +25,10 @@ func (InfinityType) IsEmpty() bool {
 	return false
 }
 
+func (InfinityType) String() string {
+	return "∞"
+}
+
 var NegativeInfinity = NegativeInfinityType{}
 
 type NegativeInfinityType struct{}
@@ -49,3 +53,7 @@ func (NegativeInfinityType) IsAny() bool {
 func (NegativeInfinityType) IsEmpty() bool {
 	return false
 }
+
+func (NegativeInfinityType) String() string {
+	return "-∞"
+}
@@ -16,6 +16,18 @@ func NewUint64(s string) (Uint64, error) {
 	return Uint64(n), nil
 }
 
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func (s Uint64) Compare(other Part) int {
 	if other == nil {
 		return 1
@@ -40,6 +52,10 @@ func (s Uint64) Compare(other Part) int {
 			return 0
 		}
 		return s.Compare(Uint64(0))
+	case InfinityType:
+		return -1
+	case NegativeInfinityType:
+		return 1
 	default:
 		panic("unknown type")
 	}
@@ -56,3 +72,7 @@ func (s Uint64) IsAny() bool {
 func (s Uint64) IsEmpty() bool {
 	return false
 }
+
+func (s Uint64) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
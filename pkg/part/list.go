@@ -1,7 +1,6 @@
 package part
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 )
@@ -9,15 +8,19 @@ import (
 type Parts []Part
 
 func NewParts(s string) Parts {
-	var parts []Part
 	if s == "" {
-		return parts
+		return nil
 	}
 
-	for _, p := range strings.Split(s, ".") {
-		parts = append(parts, NewPart(p))
+	parts := make(Parts, 0, strings.Count(s, ".")+1)
+	for {
+		i := strings.IndexByte(s, '.')
+		if i < 0 {
+			return append(parts, NewPart(s))
+		}
+		parts = append(parts, NewPart(s[:i]))
+		s = s[i+1:]
 	}
-	return parts
 }
 
 func (parts Parts) Normalize() Parts {
@@ -112,7 +115,7 @@ func (parts Parts) IsEmpty() bool {
 func (parts Parts) String() string {
 	s := make([]string, len(parts))
 	for i, p := range parts {
-		s[i] = fmt.Sprint(p)
+		s[i] = p.String()
 	}
 	return strings.Join(s, ".")
 }
@@ -0,0 +1,48 @@
+package part
+
+import (
+	"cmp"
+	"strings"
+)
+
+// CompareBuildMetadata compares two build metadata identifier by identifier.
+// Build metadata has no precedence in SemVer, but a total order is useful for deterministic sorting.
+// No build metadata sorts first, numeric identifiers are compared numerically and sort before
+// alphanumeric ones, which are compared lexically, and a shorter set of identifiers sorts first
+// if all the preceding identifiers are equal.
+func CompareBuildMetadata(m1, m2 string) int {
+	switch {
+	case m1 == m2:
+		return 0
+	case m1 == "":
+		return -1
+	case m2 == "":
+		return 1
+	}
+
+	ids1, ids2 := strings.Split(m1, "."), strings.Split(m2, ".")
+	for i := 0; i < len(ids1) && i < len(ids2); i++ {
+		if result := compareIdentifier(ids1[i], ids2[i]); result != 0 {
+			return result
+		}
+	}
+	return cmp.Compare(len(ids1), len(ids2))
+}
+
+func compareIdentifier(s1, s2 string) int {
+	n1, n2 := isNumber(s1), isNumber(s2)
+	switch {
+	case n1 && n2:
+		// Compare as strings so that numbers larger than uint64 are also ordered
+		s1, s2 = strings.TrimLeft(s1, "0"), strings.TrimLeft(s2, "0")
+		if result := cmp.Compare(len(s1), len(s2)); result != 0 {
+			return result
+		}
+		return strings.Compare(s1, s2)
+	case n1:
+		return -1
+	case n2:
+		return 1
+	}
+	return strings.Compare(s1, s2)
+}
@@ -1,6 +1,11 @@
 package part
 
+import "fmt"
+
+// Part is a component of versions such as a number, an identifier or a wildcard.
+// String returns "*" for wildcards, "" for missing parts and "∞" for Infinity.
 type Part interface {
+	fmt.Stringer
 	Compare(Part) int
 	IsNull() bool
 	IsAny() bool
@@ -8,14 +13,14 @@ type Part interface {
 }
 
 func NewPart(s string) Part {
-	var p Part
-	p, err := NewUint64(s)
-	if err == nil {
-		return p
+	// Avoid building errors for parts that are obviously not numbers or wildcards
+	if isNumber(s) {
+		if p, err := NewUint64(s); err == nil {
+			return p
+		}
 	}
-	p, err = NewAny(s)
-	if err == nil {
-		return p
+	if isAny(s) {
+		return Any(true)
 	}
 	return NewString(s)
 }
@@ -0,0 +1,34 @@
+package part
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"golang.org/x/xerrors"
+)
+
+// AppendUint64Key appends an order-preserving encoding of n to b.
+// The encoding is the number of significant bytes followed by the bytes in big-endian,
+// so that bytes.Compare on two encodings equals Uint64.Compare.
+func AppendUint64Key(b []byte, n Uint64) []byte {
+	size := (bits.Len64(uint64(n)) + 7) / 8
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	b = append(b, byte(size))
+	return append(b, buf[8-size:]...)
+}
+
+// ReadUint64Key decodes a number encoded by AppendUint64Key and returns the rest of b.
+func ReadUint64Key(b []byte) (Uint64, []byte, error) {
+	if len(b) == 0 {
+		return 0, nil, xerrors.New("truncated number in sort key")
+	}
+	size := int(b[0])
+	if size > 8 || len(b) < 1+size {
+		return 0, nil, xerrors.New("invalid number in sort key")
+	}
+
+	var buf [8]byte
+	copy(buf[8-size:], b[1:1+size])
+	return Uint64(binary.BigEndian.Uint64(buf[:])), b[1+size:], nil
+}
@@ -31,6 +31,10 @@ func (s String) Compare(other Part) int {
 			return 0
 		}
 		return s.Compare(Uint64(0))
+	case InfinityType:
+		return -1
+	case NegativeInfinityType:
+		return 1
 	}
 	return 0
 }
@@ -47,6 +51,10 @@ func (s String) IsEmpty() bool {
 	return false
 }
 
+func (s String) String() string {
+	return string(s)
+}
+
 // PreString is less than the number
 // e.g. a < 1
 type PreString string
@@ -77,6 +85,10 @@ func (s PreString) Compare(other Part) int {
 		}
 
 		return s.Compare(Uint64(0))
+	case InfinityType:
+		return -1
+	case NegativeInfinityType:
+		return 1
 	}
 	return 0
 }
@@ -92,3 +104,7 @@ func (s PreString) IsAny() bool {
 func (s PreString) IsEmpty() bool {
 	return false
 }
+
+func (s PreString) String() string {
+	return string(s)
+}
@@ -12,6 +12,9 @@ func Compare(p1, p2 part.Parts) int {
 		return 0
 	case p1.IsAny() || p2.IsAny():
 		return 0
+	case p1.IsNull() && p2.IsNull():
+		// nil and empty parts
+		return 0
 	case p1.IsNull():
 		return 1
 	case p2.IsNull():
@@ -82,3 +82,8 @@ func TestCompare(t *testing.T) {
 		})
 	}
 }
+
+func TestCompare_Empty(t *testing.T) {
+	assert.Equal(t, 0, Compare(nil, part.Parts{}))
+	assert.Equal(t, 0, Compare(part.Parts{}, nil))
+}
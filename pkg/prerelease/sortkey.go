@@ -0,0 +1,114 @@
+package prerelease
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// Tags in sort keys
+const (
+	keyEnd     byte = 0x00
+	keyNumber  byte = 0x01
+	keyString  byte = 0x02
+	keyRelease byte = 0x03
+)
+
+// AppendSortKey appends an order-preserving encoding of the pre-release p to b,
+// so that bytes.Compare on two encodings equals Compare.
+// Numeric identifiers sort before alphanumeric ones, and a release (no pre-release)
+// sorts after any pre-release.
+func AppendSortKey(b []byte, p part.Parts) []byte {
+	if len(p) == 0 {
+		return append(b, keyRelease)
+	}
+
+	for _, id := range p {
+		switch v := id.(type) {
+		case part.Uint64:
+			b = append(b, keyNumber)
+			b = part.AppendUint64Key(b, v)
+		case part.String:
+			b = appendStringKey(b, string(v))
+		default:
+			b = appendStringKey(b, fmt.Sprint(v))
+		}
+	}
+	return append(b, keyEnd)
+}
+
+// appendStringKey appends s terminated by 0x00 0x00. 0x00 in s is escaped as 0x00 0xFF.
+func appendStringKey(b []byte, s string) []byte {
+	b = append(b, keyString)
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x00 {
+			b = append(b, 0x00, 0xFF)
+			continue
+		}
+		b = append(b, s[i])
+	}
+	return append(b, 0x00, 0x00)
+}
+
+// ReadSortKey decodes a pre-release encoded by AppendSortKey and returns the rest of b.
+func ReadSortKey(b []byte) (part.Parts, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, xerrors.New("truncated pre-release in sort key")
+	} else if b[0] == keyRelease {
+		return nil, b[1:], nil
+	}
+
+	var p part.Parts
+	for {
+		if len(b) == 0 {
+			return nil, nil, xerrors.New("truncated pre-release in sort key")
+		}
+
+		tag := b[0]
+		b = b[1:]
+		switch tag {
+		case keyEnd:
+			if len(p) == 0 {
+				return nil, nil, xerrors.New("empty pre-release in sort key")
+			}
+			return p, b, nil
+		case keyNumber:
+			n, rest, err := part.ReadUint64Key(b)
+			if err != nil {
+				return nil, nil, err
+			}
+			p, b = append(p, n), rest
+		case keyString:
+			s, rest, err := readStringKey(b)
+			if err != nil {
+				return nil, nil, err
+			}
+			p, b = append(p, part.NewString(s)), rest
+		default:
+			return nil, nil, xerrors.Errorf("unknown tag in sort key: 0x%02x", tag)
+		}
+	}
+}
+
+func readStringKey(b []byte) (string, []byte, error) {
+	var buf bytes.Buffer
+	for i := 0; i+1 < len(b); i++ {
+		if b[i] != 0x00 {
+			buf.WriteByte(b[i])
+			continue
+		}
+		switch b[i+1] {
+		case 0x00:
+			return buf.String(), b[i+2:], nil
+		case 0xFF:
+			buf.WriteByte(0x00)
+			i++
+		default:
+			return "", nil, xerrors.New("invalid escape in sort key")
+		}
+	}
+	return "", nil, xerrors.New("unterminated string in sort key")
+}
@@ -0,0 +1,44 @@
+package prerelease
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+func TestAppendSortKey(t *testing.T) {
+	preReleases := []part.Parts{
+		part.NewParts("1"),
+		part.NewParts("1.a"),
+		part.NewParts("256"),
+		part.NewParts("a"),
+		{part.NewString("a\x00")},
+		{part.NewString("a\x00b")},
+		{part.NewString("a\x01")},
+		part.NewParts("a.1"),
+		part.NewParts("a.b"),
+		part.NewParts("ab"),
+		nil,
+	}
+
+	for _, p1 := range preReleases {
+		for _, p2 := range preReleases {
+			t.Run(fmt.Sprintf("%q vs %q", p1, p2), func(t *testing.T) {
+				k1, k2 := AppendSortKey(nil, p1), AppendSortKey(nil, p2)
+				assert.Equal(t, Compare(p1, p2), bytes.Compare(k1, k2))
+			})
+		}
+	}
+
+	for _, p := range preReleases {
+		got, rest, err := ReadSortKey(append(AppendSortKey(nil, p), 0xAB))
+		require.NoError(t, err)
+		assert.Equal(t, p, got)
+		assert.Equal(t, []byte{0xAB}, rest)
+	}
+}
@@ -0,0 +1,222 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestConstraints_Comparators(t *testing.T) {
+	type comparator struct {
+		operator semver.Operator
+		version  string
+		original string
+		rendered string
+	}
+	tests := []struct {
+		constraint string
+		want       [][]comparator
+	}{
+		{
+			constraint: ">= 1.2, < 2 || 3.0.0 - 3.1",
+			want: [][]comparator{
+				{
+					{semver.OpGreaterThanOrEqual, "1.2", ">= 1.2", ">=1.2"},
+					{semver.OpLessThan, "2", "< 2", "<2"},
+				},
+				{
+					{semver.OpGreaterThanOrEqual, "3.0.0", ">=3.0.0", ">=3.0.0"},
+					{semver.OpLessThan, "3.2.0-0", "<3.2.0-0", "<3.2.0-0"},
+				},
+			},
+		},
+		{
+			constraint: "=> 1.x <=2.3.4-beta.1 !=1.5.0",
+			want: [][]comparator{
+				{
+					{semver.OpGreaterThanOrEqual, "1.x", "=> 1.x", ">=1.x"},
+					{semver.OpLessThanOrEqual, "2.3.4-beta.1", "<=2.3.4-beta.1", "<=2.3.4-beta.1"},
+					{semver.OpNotEqual, "1.5.0", "!=1.5.0", "!=1.5.0"},
+				},
+			},
+		},
+		{
+			constraint: "~1.2.3 || ^0.2 || 1.0.0 || *",
+			want: [][]comparator{
+				{{semver.OpTilde, "1.2.3", "~1.2.3", "~1.2.3"}},
+				{{semver.OpCaret, "0.2", "^0.2", "^0.2"}},
+				{{semver.OpEqual, "1.0.0", "1.0.0", "=1.0.0"}},
+				{{semver.OpEqual, "*", "*", "*"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := semver.NewConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			var got [][]comparator
+			for _, ands := range c.Comparators() {
+				var cs []comparator
+				for _, a := range ands {
+					cs = append(cs, comparator{a.Operator, a.Version.String(), a.Original, a.String()})
+				}
+				got = append(got, cs)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConstraints_Walk(t *testing.T) {
+	c, err := semver.NewConstraints(">=1.2.3 <2 || ^3.1 || 4.0.0 - 4.2.0")
+	require.NoError(t, err)
+
+	var got []string
+	c.Walk(func(c semver.Comparator) bool {
+		got = append(got, c.String())
+		return true
+	})
+	assert.Equal(t, []string{">=1.2.3", "<2", "^3.1", ">=4.0.0", "<=4.2.0"}, got)
+
+	// Stop walking
+	got = nil
+	c.Walk(func(c semver.Comparator) bool {
+		got = append(got, c.String())
+		return c.Operator != semver.OpCaret
+	})
+	assert.Equal(t, []string{">=1.2.3", "<2", "^3.1"}, got)
+}
+
+func TestConstraints_Rewrite(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		fn         func(semver.Comparator) []semver.Comparator
+		want       string
+		check      map[string]bool
+	}{
+		{
+			name:       "identity",
+			constraint: "=> 1.2, =<2 || 3.0.0 - 3.1 || 5",
+			fn: func(c semver.Comparator) []semver.Comparator {
+				return []semver.Comparator{c}
+			},
+			want: ">=1.2,<=2||>=3.0.0,<3.2.0-0||=5",
+			check: map[string]bool{
+				"2.9.9": true,
+				"3.1.5": true,
+				"3.2.0": false,
+				"5.1.0": true,
+			},
+		},
+		{
+			name:       "expand caret",
+			constraint: "^1.2.3 || ^2.0.0-rc.1",
+			fn: func(c semver.Comparator) []semver.Comparator {
+				if c.Operator != semver.OpCaret {
+					return []semver.Comparator{c}
+				}
+				upper, err := c.Version.Bump(semver.LevelMajor)
+				require.NoError(t, err)
+				upper, err = upper.WithPreRelease("0")
+				require.NoError(t, err)
+				return []semver.Comparator{
+					{Operator: semver.OpGreaterThanOrEqual, Version: c.Version},
+					{Operator: semver.OpLessThan, Version: upper},
+				}
+			},
+			want: ">=1.2.3,<2.0.0-0||>=2.0.0-rc.1,<3.0.0-0",
+			check: map[string]bool{
+				"1.9.0":      true,
+				"2.0.0":      true,
+				"2.0.0-rc.2": true,
+				"3.0.0":      false,
+			},
+		},
+		{
+			name:       "remove",
+			constraint: ">=1.2.3, !=1.5.0 || 3.x",
+			fn: func(c semver.Comparator) []semver.Comparator {
+				if c.Operator == semver.OpNotEqual {
+					return nil
+				}
+				return []semver.Comparator{c}
+			},
+			want: ">=1.2.3||=3.x",
+			check: map[string]bool{
+				"1.5.0": true,
+				"1.0.0": false,
+				"3.4.0": true,
+			},
+		},
+		{
+			name:       "remove branch",
+			constraint: "<1.0.0 || >=5.0.0",
+			fn: func(c semver.Comparator) []semver.Comparator {
+				if c.Operator == semver.OpGreaterThanOrEqual {
+					return nil
+				}
+				return []semver.Comparator{c}
+			},
+			want: "<1.0.0",
+			check: map[string]bool{
+				"0.9.0": true,
+				"3.0.0": false,
+				"5.0.0": false,
+			},
+		},
+		{
+			name:       "replace versions",
+			constraint: ">=1.2.3 <1.3.0",
+			fn: func(c semver.Comparator) []semver.Comparator {
+				c.Version = c.Version.WithMinor(uint64(c.Version.Minor().(part.Uint64)) + 1)
+				return []semver.Comparator{c}
+			},
+			want: ">=1.3.3,<1.4.0",
+			check: map[string]bool{
+				"1.2.3": false,
+				"1.3.3": true,
+				"1.4.0": false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := semver.NewConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			got, err := c.Rewrite(tt.fn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+
+			for v, want := range tt.check {
+				assert.Equal(t, want, got.Check(semver.MustParse(v)), v)
+			}
+		})
+	}
+}
+
+func TestConstraints_RewriteError(t *testing.T) {
+	c, err := semver.NewConstraints(">=1.2.3")
+	require.NoError(t, err)
+
+	_, err = c.Rewrite(func(semver.Comparator) []semver.Comparator {
+		return []semver.Comparator{{Operator: semver.OpLessThan}}
+	})
+	assert.ErrorIs(t, err, semver.ErrInvalidConstraint)
+}
+
+func TestConstraints_RewriteRemoveAll(t *testing.T) {
+	c, err := semver.NewConstraints("<1.0.0 || >=5.0.0")
+	require.NoError(t, err)
+
+	_, err = c.Rewrite(func(semver.Comparator) []semver.Comparator {
+		return nil
+	})
+	assert.ErrorIs(t, err, semver.ErrInvalidConstraint)
+}
@@ -0,0 +1,278 @@
+package semver
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/xerrors"
+)
+
+// Fix describes an adjustment Coerce made to its input.
+type Fix uint
+
+const (
+	// FixTrimmedSpace means leading or trailing whitespace was removed.
+	FixTrimmedSpace Fix = 1 << iota
+	// FixRemovedPrefix means text before the version was removed, e.g. "v" or "version ".
+	FixRemovedPrefix
+	// FixRemovedSuffix means text after the version was removed.
+	FixRemovedSuffix
+	// FixAddedMissingParts means a missing minor or patch version was filled with 0.
+	FixAddedMissingParts
+	// FixDroppedExtraParts means numbers after the patch version were dropped, e.g. 1.2.3.4 => 1.2.3
+	FixDroppedExtraParts
+	// FixRemovedLeadingZeros means leading zeros were removed from numbers, e.g. 01.02.03 => 1.2.3
+	FixRemovedLeadingZeros
+	// FixPreReleaseSeparator means the pre-release was not separated by a hyphen, e.g. 1.2.3rc1 => 1.2.3-rc1
+	FixPreReleaseSeparator
+	// FixNormalizedPreRelease means invalid characters, empty identifiers or
+	// leading zeros were removed from the pre-release.
+	FixNormalizedPreRelease
+	// FixNormalizedMetadata means invalid characters or empty identifiers were removed from the build metadata.
+	FixNormalizedMetadata
+	// FixDroppedPreRelease means the pre-release and build metadata were dropped because of WithDropPreRelease.
+	FixDroppedPreRelease
+)
+
+var fixNames = []string{
+	"trimmed whitespace",
+	"removed prefix",
+	"removed suffix",
+	"added missing parts",
+	"dropped extra parts",
+	"removed leading zeros",
+	"added pre-release separator",
+	"normalized pre-release",
+	"normalized build metadata",
+	"dropped pre-release",
+}
+
+func (f Fix) String() string {
+	var names []string
+	for i, name := range fixNames {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Has returns true if all the fixes in o were made.
+func (f Fix) Has(o Fix) bool {
+	return f&o == o
+}
+
+type coerceConf struct {
+	dropPreRelease bool
+	rightToLeft    bool
+}
+
+// CoerceOption configures Coerce.
+type CoerceOption interface {
+	applyCoerce(*coerceConf)
+}
+
+// WithDropPreRelease makes Coerce discard the pre-release and build metadata.
+type WithDropPreRelease bool
+
+func (o WithDropPreRelease) applyCoerce(c *coerceConf) {
+	c.dropPreRelease = bool(o)
+}
+
+// WithRightToLeft makes Coerce use the last version found in the input instead of the first.
+// e.g. "openssl 1.1.1k (compat 3.0)" => 3.0.0
+type WithRightToLeft bool
+
+func (o WithRightToLeft) applyCoerce(c *coerceConf) {
+	c.rightToLeft = bool(o)
+}
+
+// Coerce extracts the best semantic version it can find in a given string.
+// It accepts inputs such as "v1.2", " 1.2.3 ", "1.2.3.4", "01.02.03" and "1.2.3~rc1".
+// The returned version keeps the given string in Original().
+func Coerce(v string, opts ...CoerceOption) (Version, error) {
+	ver, _, err := CoerceWithFixes(v, opts...)
+	return ver, err
+}
+
+// CoerceWithFixes works like Coerce and also returns the fixes made to the given string.
+func CoerceWithFixes(v string, opts ...CoerceOption) (Version, Fix, error) {
+	c := new(coerceConf)
+	for _, o := range opts {
+		o.applyCoerce(c)
+	}
+
+	var fixes Fix
+	s := strings.TrimSpace(v)
+	if s != v {
+		fixes |= FixTrimmedSpace
+	}
+
+	start := findCoerceStart(s, c.rightToLeft)
+	if start < 0 {
+		return Version{}, Fix(0), newVersionError(v, 0, "major", "no version found", nil)
+	}
+	if start > 0 {
+		fixes |= FixRemovedPrefix
+	}
+
+	// MAJOR.MINOR.PATCH
+	var nums []string
+	i := start
+	for {
+		j := i
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		num := strings.TrimLeft(s[i:j], "0")
+		if num == "" {
+			num = "0"
+		}
+		if num != s[i:j] {
+			fixes |= FixRemovedLeadingZeros
+		}
+		nums = append(nums, num)
+
+		i = j
+		if i+1 >= len(s) || s[i] != '.' || !isDigit(s[i+1]) {
+			break
+		}
+		i++
+	}
+	switch {
+	case len(nums) < 3:
+		fixes |= FixAddedMissingParts
+	case len(nums) > 3:
+		fixes |= FixDroppedExtraParts
+	}
+	nums = append(nums, "0", "0")[:3]
+
+	var buf strings.Builder
+	buf.WriteString(strings.Join(nums, "."))
+
+	// -PRERELEASE
+	if i < len(s) && (isIdentifierChar(s[i]) || s[i] == '~' || s[i] == '_') {
+		if s[i] != '-' {
+			fixes |= FixPreReleaseSeparator
+		}
+		if s[i] == '-' || s[i] == '~' || s[i] == '_' {
+			i++
+		}
+		j := i
+		for j < len(s) && isCoerceChar(s[j]) {
+			j++
+		}
+		pre, changed := normalizeIdentifiers(s[i:j], true)
+		if changed || pre == "" {
+			fixes |= FixNormalizedPreRelease
+		}
+		switch {
+		case c.dropPreRelease && pre != "":
+			fixes |= FixDroppedPreRelease
+		case pre != "":
+			buf.WriteString("-" + pre)
+		}
+		i = j
+	}
+
+	// +BUILDMETADATA
+	if i < len(s) && s[i] == '+' {
+		j := i + 1
+		for j < len(s) && isCoerceChar(s[j]) {
+			j++
+		}
+		metadata, changed := normalizeIdentifiers(s[i+1:j], false)
+		if changed || metadata == "" {
+			fixes |= FixNormalizedMetadata
+		}
+		switch {
+		case c.dropPreRelease && metadata != "":
+			fixes |= FixDroppedPreRelease
+		case metadata != "":
+			buf.WriteString("+" + metadata)
+		}
+		i = j
+	}
+
+	if i < len(s) {
+		fixes |= FixRemovedSuffix
+	}
+
+	ver, err := parse(buf.String(), parseConf{})
+	if err != nil {
+		// e.g. a part overflows uint64
+		// The offset in the coerced string doesn't match the given one, so the start of the version is reported.
+		component, reason := "version", "invalid version"
+		var pe *ParseError
+		if xerrors.As(err, &pe) {
+			component, reason, err = pe.Component, pe.Reason, pe.Err
+		}
+		offset := len(v) - len(strings.TrimLeftFunc(v, unicode.IsSpace)) + start
+		return Version{}, Fix(0), newVersionError(v, offset, component, reason, err)
+	}
+	ver.original = v
+	return ver, fixes, nil
+}
+
+// findCoerceStart returns the index of the first or last number
+// which is not a part of another number or version, or -1 if there is no number.
+func findCoerceStart(s string, rightToLeft bool) int {
+	start := -1
+	for i := 0; i < len(s); i++ {
+		switch {
+		case !isDigit(s[i]):
+			continue
+		case i > 0 && isDigit(s[i-1]):
+			continue
+		case i > 1 && s[i-1] == '.' && isDigit(s[i-2]):
+			continue
+		}
+		if !rightToLeft {
+			return i
+		}
+		start = i
+	}
+	return start
+}
+
+// normalizeIdentifiers replaces invalid characters in dot-separated identifiers with hyphens
+// and removes empty identifiers. It also removes leading zeros from numeric identifiers if strict is true.
+func normalizeIdentifiers(s string, strict bool) (string, bool) {
+	var ids []string
+	for _, id := range strings.Split(s, ".") {
+		id = strings.Map(func(r rune) rune {
+			if r == '_' || r == '~' {
+				return '-'
+			}
+			return r
+		}, id)
+		if id == "" {
+			continue
+		}
+		if strict && isNumber(id) {
+			if id = strings.TrimLeft(id, "0"); id == "" {
+				id = "0"
+			}
+		}
+		ids = append(ids, id)
+	}
+
+	normalized := strings.Join(ids, ".")
+	return normalized, normalized != s
+}
+
+func isCoerceChar(c byte) bool {
+	return isIdentifierChar(c) || c == '.' || c == '_' || c == '~'
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
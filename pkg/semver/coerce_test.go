@@ -0,0 +1,100 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  []semver.CoerceOption
+		want  string
+		fixes semver.Fix
+	}{
+		{input: "1.2.3", want: "1.2.3"},
+		{input: "1.2.3-alpha.1+build.5", want: "1.2.3-alpha.1+build.5"},
+		{input: "v1.2", want: "1.2.0", fixes: semver.FixRemovedPrefix | semver.FixAddedMissingParts},
+		{input: " 1.2.3 ", want: "1.2.3", fixes: semver.FixTrimmedSpace},
+		{input: "1.2.3.4", want: "1.2.3", fixes: semver.FixDroppedExtraParts},
+		{input: "01.02.03", want: "1.2.3", fixes: semver.FixRemovedLeadingZeros},
+		{input: "1.2.3-beta1-ubuntu", want: "1.2.3-beta1-ubuntu"},
+		{input: "1.7rc2", want: "1.7.0-rc2", fixes: semver.FixAddedMissingParts | semver.FixPreReleaseSeparator},
+		{input: "1.2.3~rc.01", want: "1.2.3-rc.1", fixes: semver.FixPreReleaseSeparator | semver.FixNormalizedPreRelease},
+		{input: "1.2.3-rc_1..2", want: "1.2.3-rc-1.2", fixes: semver.FixNormalizedPreRelease},
+		{input: "1.2.3-", want: "1.2.3", fixes: semver.FixNormalizedPreRelease},
+		{input: "1.2.3+build_1", want: "1.2.3+build-1", fixes: semver.FixNormalizedMetadata},
+		{input: "version 2 (stable)", want: "2.0.0", fixes: semver.FixRemovedPrefix | semver.FixAddedMissingParts | semver.FixRemovedSuffix},
+		{input: "foo.1.2", want: "1.2.0", fixes: semver.FixRemovedPrefix | semver.FixAddedMissingParts},
+		{
+			input: "1.2.3-beta+build",
+			opts:  []semver.CoerceOption{semver.WithDropPreRelease(true)},
+			want:  "1.2.3",
+			fixes: semver.FixDroppedPreRelease,
+		},
+		{
+			input: "openssl 1.1.1k (compat 3.0)",
+			opts:  []semver.CoerceOption{semver.WithRightToLeft(true)},
+			want:  "3.0.0",
+			fixes: semver.FixRemovedPrefix | semver.FixAddedMissingParts | semver.FixRemovedSuffix,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, fixes, err := semver.CoerceWithFixes(tt.input, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.input, got.Original())
+			assert.Equal(t, tt.fixes, fixes, fixes.String())
+
+			want, err := semver.Parse(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, 0, got.Compare(want))
+		})
+	}
+}
+
+func TestCoerce_Error(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantOffset int
+		component  string
+	}{
+		{input: "", wantOffset: 0, component: "major"},
+		{input: "foo", wantOffset: 0, component: "major"},
+		{input: "99999999999999999999.0.0", wantOffset: 0, component: "major"},
+		{input: "app-v0099999999999999999999.1", wantOffset: 5, component: "major"},
+		{input: " v1.99999999999999999999", wantOffset: 2, component: "minor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := semver.Coerce(tt.input)
+			assert.ErrorIs(t, err, semver.ErrInvalidSemVer)
+
+			var pe *semver.ParseError
+			require.ErrorAs(t, err, &pe)
+			assert.Equal(t, tt.input, pe.Input)
+			assert.Equal(t, tt.wantOffset, pe.Offset)
+			assert.Equal(t, tt.component, pe.Component)
+		})
+	}
+}
+
+func TestFix_String(t *testing.T) {
+	assert.Equal(t, "", semver.Fix(0).String())
+	assert.Equal(t, "trimmed whitespace, added missing parts",
+		(semver.FixTrimmedSpace | semver.FixAddedMissingParts).String())
+}
+
+func TestCoerce_Constraints(t *testing.T) {
+	v, err := semver.Coerce("v1.4")
+	require.NoError(t, err)
+
+	c, err := semver.NewConstraints(">= 1.2, < 2")
+	require.NoError(t, err)
+	assert.True(t, c.Check(v))
+}
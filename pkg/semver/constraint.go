@@ -5,8 +5,6 @@ import (
 	"regexp"
 	"strings"
 
-	"golang.org/x/xerrors"
-
 	"github.com/aquasecurity/go-version/pkg/part"
 )
 
@@ -29,8 +27,9 @@ var (
 		"~":  constraintTilde,
 		"^":  constraintCaret,
 	}
-	constraintRegexp      *regexp.Regexp
-	validConstraintRegexp *regexp.Regexp
+	constraintRegexp         *regexp.Regexp
+	validConstraintRegexp    *regexp.Regexp
+	anchoredConstraintRegexp *regexp.Regexp
 )
 
 type operatorFunc func(v, c Version) bool
@@ -50,6 +49,8 @@ func init() {
 		`^\s*(\s*(%s)\s*(%s)\s*\,?)*\s*$`,
 		strings.Join(ops, "|"),
 		cvRegex))
+
+	anchoredConstraintRegexp = regexp.MustCompile(`^(?:` + constraintRegexp.String() + `)`)
 }
 
 type Constraints struct {
@@ -61,8 +62,12 @@ type Constraints struct {
 // checked against.
 type constraint struct {
 	version  Version
+	op       Operator
 	operator operatorFunc
 	original string
+
+	// expansion holds the comparators of a hyphen range such as "1.2.3 - 2.3.4"
+	expansion []constraint
 }
 
 // NewConstraints parses a given constraint and returns a new instance of Constraints
@@ -75,26 +80,43 @@ func NewConstraints(v string, opts ...ConstraintOption) (Constraints, error) {
 	}
 
 	var css [][]constraint
+	var offset int
 	for _, vv := range strings.Split(v, "||") {
+		// Hyphen ranges must be the whole segment
+		if loc := hyphenRegexp.FindStringSubmatchIndex(vv); loc != nil {
+			hc, err := newHyphenConstraint(v, offset, vv, loc, *c)
+			if err != nil {
+				return Constraints{}, err
+			}
+			css = append(css, []constraint{hc})
+			offset += len(vv) + len("||")
+			continue
+		}
+
 		// Validate the segment
 		if !validConstraintRegexp.MatchString(vv) {
-			return Constraints{}, xerrors.Errorf("improper constraint: %s", vv)
+			return Constraints{}, constraintError(v, offset, vv)
 		}
 
-		ss := constraintRegexp.FindAllString(vv, -1)
-		if ss == nil {
-			ss = append(ss, strings.TrimSpace(vv))
+		locs := constraintRegexp.FindAllStringSubmatchIndex(vv, -1)
+		if err := checkSeparators(v, offset, vv, locs); err != nil {
+			return Constraints{}, err
+		}
+		if locs == nil {
+			// An empty segment
+			locs = [][]int{{len(vv), len(vv)}}
 		}
 
 		var cs []constraint
-		for _, single := range ss {
-			c, err := newConstraint(single, *c)
+		for _, loc := range locs {
+			sc, err := newConstraint(v, offset+loc[0], vv[loc[0]:loc[1]], *c)
 			if err != nil {
 				return Constraints{}, err
 			}
-			cs = append(cs, c)
+			cs = append(cs, sc)
 		}
 		css = append(css, cs)
+		offset += len(vv) + len("||")
 	}
 
 	return Constraints{
@@ -104,7 +126,9 @@ func NewConstraints(v string, opts ...ConstraintOption) (Constraints, error) {
 
 }
 
-func newConstraint(c string, conf conf) (constraint, error) {
+// newConstraint parses a comparator c, which starts at offset in input.
+// Errors report the position in input, as the other errors of NewConstraints do.
+func newConstraint(input string, offset int, c string, conf conf) (constraint, error) {
 	if c == "" {
 		return constraint{
 			version: Version{
@@ -119,7 +143,7 @@ func newConstraint(c string, conf conf) (constraint, error) {
 
 	m := constraintRegexp.FindStringSubmatch(c)
 	if m == nil {
-		return constraint{}, xerrors.Errorf("improper constraint: %s", c)
+		return constraint{}, newConstraintError(input, offset, "constraint", "improper constraint", nil)
 	}
 
 	major := m[3]
@@ -141,11 +165,79 @@ func newConstraint(c string, conf conf) (constraint, error) {
 
 	return constraint{
 		version:  v,
+		op:       operatorKinds[m[1]],
 		operator: constraintOperators[m[1]],
 		original: c,
 	}, nil
 }
 
+// checkSeparators rejects a comparator without an operator that is not separated from the previous one
+// by a comma, such as "3" in ">= 1.2 3" or "v4" in "1.2.3v4", since it is ambiguous.
+// Comparators separated by whitespace are ANDed like commas, e.g. ">=1.2.3 <2.0.0".
+// The version package has the same function since the two packages don't share the constraint parser.
+func checkSeparators(input string, offset int, segment string, locs [][]int) error {
+	for i := 1; i < len(locs); i++ {
+		opStart, opEnd, start := locs[i][2], locs[i][3], locs[i][4]
+		if opStart != opEnd || strings.Contains(segment[locs[i-1][1]:start], ",") {
+			continue
+		}
+		return newConstraintError(input, offset+start, "operator",
+			fmt.Sprintf("missing operator or comma before version %q", segment[start:locs[i][5]]), nil)
+	}
+	return nil
+}
+
+// constraintError locates the first problem in the invalid segment of input
+// starting at offset.
+func constraintError(input string, offset int, segment string) error {
+	i := 0
+	for {
+		i = skipSpace(segment, i)
+		if i == len(segment) {
+			break
+		}
+
+		loc := anchoredConstraintRegexp.FindStringIndex(segment[i:])
+		if loc == nil {
+			return describeConstraintError(input, offset, segment, i)
+		}
+		i = skipSpace(segment, i+loc[1])
+		if i < len(segment) && segment[i] == ',' {
+			i++
+		}
+	}
+	return newConstraintError(input, offset, "constraint", "improper constraint", nil)
+}
+
+// describeConstraintError explains why no constraint could be matched at i.
+func describeConstraintError(input string, offset int, segment string, i int) error {
+	j := i
+	for j < len(segment) && strings.IndexByte("<>=!~^", segment[j]) >= 0 {
+		j++
+	}
+	if op := segment[i:j]; op != "" {
+		if _, ok := constraintOperators[op]; !ok {
+			return newConstraintError(input, offset+i, "operator", fmt.Sprintf("unknown operator %q", op), nil)
+		}
+	}
+
+	j = skipSpace(segment, j)
+	switch {
+	case j == len(segment):
+		return newConstraintError(input, offset+j, "version", "missing version", nil)
+	case strings.IndexByte("vxX*0123456789", segment[j]) < 0:
+		return newConstraintError(input, offset+j, "version", fmt.Sprintf("unexpected character %q", segment[j]), nil)
+	}
+	return newConstraintError(input, offset+j, "version", "invalid version", nil)
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && strings.IndexByte(" \t\n\f\r", s[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
 func newPart(p string, conf conf) part.Part {
 	if p == "" {
 		return part.NewEmpty(!conf.zeroPadding)
@@ -154,6 +246,9 @@ func newPart(p string, conf conf) part.Part {
 }
 
 func (c constraint) check(v Version, conf conf) bool {
+	if c.expansion != nil {
+		return andCheck(v, c.expansion, conf)
+	}
 	op := preCheck(c.operator, conf)
 	return op(v, c.version)
 }
@@ -162,10 +257,24 @@ func (c constraint) String() string {
 	return c.original
 }
 
+// expandedString returns the comparators of a hyphen range separated by a space,
+// e.g. ">=1.2.3 <=2.3.4", or the original string of the other constraints.
+func (c constraint) expandedString() string {
+	if c.expansion == nil {
+		return c.original
+	}
+
+	s := make([]string, len(c.expansion))
+	for i, e := range c.expansion {
+		s[i] = e.original
+	}
+	return strings.Join(s, " ")
+}
+
 // Check tests if a version satisfies all the constraints.
 func (cs Constraints) Check(v Version) bool {
 	for _, c := range cs.constraints {
-		if andCheck(v, c, cs.conf) {
+		if andCheck(v, c, cs.conf) && npmPreCheck(v, c, cs.conf) {
 			return true
 		}
 	}
@@ -173,12 +282,17 @@ func (cs Constraints) Check(v Version) bool {
 	return false
 }
 
-// Returns the string format of the constraints
+// Returns the string format of the constraints.
+// Hyphen ranges are expanded with WithHyphenRangeExpansion(true).
 func (cs Constraints) String() string {
 	var csStr []string
 	for _, orC := range cs.constraints {
 		var cstr []string
 		for _, andC := range orC {
+			if cs.conf.expandHyphenRanges {
+				cstr = append(cstr, andC.expandedString())
+				continue
+			}
 			cstr = append(cstr, andC.String())
 		}
 		csStr = append(csStr, strings.Join(cstr, ","))
@@ -249,7 +363,13 @@ func constraintCaret(v, c Version) bool {
 
 func preCheck(f operatorFunc, conf conf) operatorFunc {
 	return func(v, c Version) bool {
-		if !conf.includePreRelease && (!v.preRelease.IsNull() && c.preRelease.IsNull()) {
+		if conf.npmPreRelease && !conf.includePreRelease {
+			// pre-releases are checked against the whole AND set in npmPreCheck
+			if !c.preRelease.IsNull() && c.IsAny() {
+				return false
+			}
+			return f(v, c)
+		} else if !conf.includePreRelease && (!v.preRelease.IsNull() && c.preRelease.IsNull()) {
 			return false
 		} else if !c.preRelease.IsNull() && c.IsAny() {
 			return false
@@ -257,3 +377,35 @@ func preCheck(f operatorFunc, conf conf) operatorFunc {
 		return f(v, c)
 	}
 }
+
+// npmPreCheck tests if a pre-release version is allowed by comparators ANDed together in the same way as npm.
+// It is allowed only if one of the comparators has a pre-release with the same major.minor.patch tuple.
+// ref. testSet in https://github.com/npm/node-semver/blob/v7.6.3/classes/range.js
+func npmPreCheck(v Version, constraints []constraint, conf conf) bool {
+	if !conf.npmPreRelease || conf.includePreRelease || v.preRelease.IsNull() {
+		return true
+	}
+	for _, c := range constraints {
+		if c.expansion != nil {
+			if npmPreCheck(v, c.expansion, conf) {
+				return true
+			}
+			continue
+		}
+		if !c.version.preRelease.IsNull() && sameTuple(v, c.version) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameTuple tests if the constraint version c has the same major.minor.patch tuple as v.
+// Wildcards never match.
+func sameTuple(v, c Version) bool {
+	for _, p := range [][2]part.Part{{c.major, v.major}, {c.minor, v.minor}, {c.patch, v.patch}} {
+		if p[0].IsAny() || p[0].Compare(p[1]) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -1,8 +1,10 @@
 package semver
 
 type conf struct {
-	zeroPadding       bool
-	includePreRelease bool
+	zeroPadding        bool
+	includePreRelease  bool
+	expandHyphenRanges bool
+	npmPreRelease      bool
 }
 
 type ConstraintOption interface {
@@ -20,3 +22,21 @@ type WithPreRelease bool
 func (o WithPreRelease) apply(c *conf) {
 	c.includePreRelease = bool(o)
 }
+
+// WithHyphenRangeExpansion makes Constraints.String() show hyphen ranges as comparators
+// in the same way as npm, e.g. "1.2.3 - 2.3" => ">=1.2.3 <2.4.0-0".
+type WithHyphenRangeExpansion bool
+
+func (o WithHyphenRangeExpansion) apply(c *conf) {
+	c.expandHyphenRanges = bool(o)
+}
+
+// WithNpmPrereleaseSemantics makes pre-release versions satisfy constraints in the same way as npm.
+// A pre-release version is allowed only if a comparator in the same AND set has a pre-release
+// with the same major.minor.patch tuple, e.g. ">= 2.0.0-alpha" allows 2.0.0-beta, but not 2.1.0-alpha.
+// WithPreRelease(true) takes precedence over it.
+type WithNpmPrereleaseSemantics bool
+
+func (o WithNpmPrereleaseSemantics) apply(c *conf) {
+	c.npmPreRelease = bool(o)
+}
@@ -2,6 +2,7 @@ package semver
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,8 +25,16 @@ func TestNewConstraints(t *testing.T) {
 		{">= 1.2.3, < 2.0", false},
 		{">= 1.2.3, < 2.0 || => 3.0, < 4", false},
 
-		// The 3 - 4 should be broken into 2 by the range rewriting
-		//{"3 - 4 || => 3.0, < 4", false},
+		// Test with whitespace separating AND
+		{">=1.2.3 <2.0.0", false},
+		{">= 1.2.3 < 2.0 || >=3.0 <4", false},
+		{">= 1.2 3", true},
+
+		// Hyphen ranges
+		{"3 - 4 || => 3.0, < 4", false},
+		{"1.2.3 - 2.3.4", false},
+		{"1.2.3 - >=2.3.4", true},
+		{">=1.2.3 - 2.3.4", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
@@ -155,6 +164,8 @@ func TestConstraint_Check(t *testing.T) {
 		{"~0.2.3", "0.2.5", true},
 		{"~0.2.3", "0.3.5", false},
 		{"~1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"~1.18446744073709551615", "1.18446744073709551615.1", true},
+		{"~18446744073709551615", "18446744073709551615.1.0", true},
 
 		// Caret
 		// https://docs.npmjs.com/cli/v6/using-npm/semver#caret-ranges-123-025-004
@@ -181,6 +192,8 @@ func TestConstraint_Check(t *testing.T) {
 		{"^0", "0.2.3", true},
 		{"^0", "1.1.4", false},
 		{"^0.2.3-beta.2", "0.2.3-beta.4", true},
+		{"^18446744073709551615", "18446744073709551615.1.0", true},
+		{"^0.0.18446744073709551615", "0.1.0", false},
 
 		// This next test is a case that is different from npm/js semver handling.
 		// Their prereleases are only range scoped to patch releases. This is
@@ -595,6 +608,67 @@ func TestConstraint_CheckWithPreReleaseAndZeroPadding(t *testing.T) {
 	}
 }
 
+func TestConstraints_CheckWithNpmPrereleaseSemantics(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		// ref. https://github.com/npm/node-semver/blob/v7.6.3/test/fixtures/range-include.js
+		{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3-pre.2", true},
+		{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "2.4.3-alpha", true},
+		{"1.2.3-pre+asdf - 2.4.3-pre+asdf", "1.2.3", true},
+		{"^1.2.0-alpha", "1.2.0-pre", true},
+		{"^0.0.1-alpha", "0.0.1-beta", true},
+		{"^0.1.1-alpha", "0.1.1-beta", true},
+		{"~1.2.3-beta.2", "1.2.3-beta.4", true},
+		{">=0.0.1-alpha <0.2.0", "0.0.1-beta", true},
+		{">=1.0.0-alpha, <1.0.0-beta", "1.0.0-alpha.1", true},
+
+		// ref. https://github.com/npm/node-semver/blob/v7.6.3/test/fixtures/range-exclude.js
+		{"^1.2.3", "1.2.3-pre", false},
+		{"^1.2", "1.2.0-pre", false},
+		{">1.2", "1.3.0-beta", false},
+		{"<=1.2.3", "1.2.3-beta", false},
+		{"^1.2.3", "1.2.3-beta", false},
+		{"=0.7.x", "0.7.0-asdf", false},
+		{">=0.7.x", "0.7.0-asdf", false},
+		{"<=0.7.x", "0.7.0-asdf", false},
+		{"1.2.3+asdf - 2.4.3+asdf", "1.2.3-pre.2", false},
+		{"1.2.3+asdf - 2.4.3+asdf", "2.4.3-alpha", false},
+		{"^1.2.3-beta.2", "1.2.4-beta.2", false},
+		{"~1.2.3-beta.2", "1.2.4-beta.2", false},
+
+		// The comparator with a pre-release must have the same major.minor.patch tuple
+		{">= 2.0.0-alpha", "2.0.0-beta", true},
+		{">= 2.0.0-alpha", "2.1.0-alpha", false},
+		{">= 2.0.0-alpha", "2.1.0", true},
+		{">= 2.0.0-alpha || >= 2.1.0-alpha", "2.1.0-beta", true},
+		{"<2.0.0-rc.1 >=1.0.0", "2.0.0-beta", true},
+		{"1.x - 2.0.0-rc.1", "1.5.0-beta", false},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s vs %s", tc.constraint, tc.version), func(t *testing.T) {
+			c, err := NewConstraints(tc.constraint, WithNpmPrereleaseSemantics(true))
+			require.NoError(t, err)
+
+			v, err := Parse(tc.version)
+			require.NoError(t, err)
+
+			got := c.Check(v)
+			assert.Equal(t, tc.want, got)
+
+			// WithPreRelease(true) takes precedence
+			c, err = NewConstraints(tc.constraint, WithNpmPrereleaseSemantics(true), WithPreRelease(true))
+			require.NoError(t, err)
+
+			want, err := NewConstraints(tc.constraint, WithPreRelease(true))
+			require.NoError(t, err)
+			assert.Equal(t, want.Check(v), c.Check(v))
+		})
+	}
+}
+
 func TestConstraints_Check(t *testing.T) {
 	tests := []struct {
 		constraint string
@@ -602,6 +676,9 @@ func TestConstraints_Check(t *testing.T) {
 		want       bool
 	}{
 		{"*", "1.2.3", true},
+		{">=1.2.3 <2.0.0", "1.5.0", true},
+		{">=1.2.3 <2.0.0", "2.0.0", false},
+		{">= 1.2.3 < 2.0.0, !=1.5.0", "1.5.0", false},
 		{"~0.0.0", "1.2.3", false},
 		{"0.x.x", "1.2.3", false},
 		{"0.0.x", "1.2.3", false},
@@ -712,3 +789,92 @@ func TestConstraints_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestNewConstraints_Error(t *testing.T) {
+	tests := []struct {
+		input     string
+		offset    int
+		component string
+		reason    string
+	}{
+		{">= bar", 3, "version", `unexpected character 'b'`},
+		{"BAR >= 1.2.3", 0, "version", `unexpected character 'B'`},
+		{">> 1.2.3", 0, "operator", `unknown operator ">>"`},
+		{">= 1.2.3, < 2.0 || ~> 3.0", 19, "operator", `unknown operator "~>"`},
+		{">= 1.2.3, <", 11, "version", "missing version"},
+		{">= 1.2.3.4", 8, "version", `unexpected character '.'`},
+		{"1.2.3 - 2.3.y", 8, "version", "invalid version in hyphen range"},
+		{">= 1.0 || >=1.2.3 - 2", 10, "version", "invalid version in hyphen range"},
+		{"1 - 18446744073709551615", 4, "version", "invalid major version in hyphen range"},
+		{">= 1.2 3", 7, "operator", `missing operator or comma before version "3"`},
+		{"^1.0 || 1.2.3 2.0.0", 14, "operator", `missing operator or comma before version "2.0.0"`},
+		{"1.2.3v4", 5, "operator", `missing operator or comma before version "v4"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := NewConstraints(tt.input)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidConstraint)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.input, perr.Input)
+			assert.Equal(t, tt.offset, perr.Offset)
+			assert.Equal(t, tt.component, perr.Component)
+			assert.Equal(t, tt.reason, perr.Reason)
+		})
+	}
+}
+
+func TestConstraints_HyphenRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expanded   string
+		version    string
+		want       bool
+	}{
+		// ref. https://github.com/npm/node-semver/blob/v7.6.3/test/fixtures/range-include.js
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "1.2.3", true},
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "2.0.0", true},
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "2.0.1", false},
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "0.9.9", false},
+		{"1.2.3 - 2.3.4", ">=1.2.3 <=2.3.4", "2.3.4", true},
+		{"1.2 - 2.3.4", ">=1.2.0 <=2.3.4", "1.2.0", true},
+		{"1.2 - 2.3.4", ">=1.2.0 <=2.3.4", "1.1.9", false},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4.0-0", "2.3.9", true},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4.0-0", "2.4.0", false},
+		{"1.2.3 - 2.3.x", ">=1.2.3 <2.4.0-0", "2.3.9", true},
+		{"1.2.3 - 2", ">=1.2.3 <3.0.0-0", "2.9.9", true},
+		{"1.2.3 - 2", ">=1.2.3 <3.0.0-0", "3.0.0", false},
+		{"1.2.3 - 2", ">=1.2.3 <3.0.0-0", "3.0.0-rc.1", false},
+		{"v1.2.3 - v2.3.4", ">=1.2.3 <=2.3.4", "2.0.0", true},
+		{"1.x - 2", ">=1.0.0 <3.0.0-0", "1.0.0", true},
+		{"* - 2", "<3.0.0-0", "0.0.1", true},
+		{"1.2.3 - *", ">=1.2.3", "99.0.0", true},
+		{"* - *", "*", "1.2.3", true},
+		{"1.2.3-beta.1 - 2.3.4-rc.1", ">=1.2.3-beta.1 <=2.3.4-rc.1", "2.3.4-beta", true},
+		{"1.2.3 - 2.3.4||>=3", ">=1.2.3 <=2.3.4||>=3", "3.1.0", true},
+		{" 1.2.3   -   2.3.4 ", ">=1.2.3 <=2.3.4", "1.5.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.constraint, tt.version), func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, c.Check(v))
+			assert.Equal(t, strings.TrimSpace(tt.constraint), c.String())
+
+			expanded, err := NewConstraints(tt.constraint, WithHyphenRangeExpansion(true))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expanded, expanded.String())
+
+			// The expansion is equivalent to the hyphen range
+			e, err := NewConstraints(tt.expanded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, e.Check(v))
+		})
+	}
+}
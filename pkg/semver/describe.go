@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// e.g. v1.4.2-17-g3a9c1f2-dirty
+var describeRegexp = regexp.MustCompile(`^(.+?)(?:-([0-9]+)-g([0-9a-f]+))?(-dirty)?$`)
+
+// GitDescribe represents the output of "git describe --tags",
+// i.e. the nearest tag, the number of commits since the tag, the abbreviated commit hash and the dirty flag.
+type GitDescribe struct {
+	tag      Version
+	distance uint64
+	hash     string
+	dirty    bool
+	version  Version
+	original string
+}
+
+// ParseGitDescribe parses the output of "git describe --tags" such as v1.4.2, v1.4.2-17-g3a9c1f2
+// and v1.4.2-17-g3a9c1f2-dirty. The tag is parsed with the given options, and the "v" prefix is accepted by default.
+func ParseGitDescribe(s string, opts ...ParseOption) (GitDescribe, error) {
+	m := describeRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return GitDescribe{}, newVersionError(s, 0, "version", "empty version", nil)
+	}
+
+	tag, err := Parse(m[1], append([]ParseOption{WithVPrefix(true)}, opts...)...)
+	if err != nil {
+		return GitDescribe{}, xerrors.Errorf("invalid tag in %q: %w", s, err)
+	}
+
+	d := GitDescribe{
+		tag:      tag,
+		hash:     m[3],
+		dirty:    m[4] != "",
+		original: s,
+	}
+	if m[2] != "" {
+		if d.distance, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+			return GitDescribe{}, newVersionError(s, len(m[1])+1, "distance", "invalid commit distance", err)
+		}
+	}
+
+	if d.version, err = d.buildVersion(); err != nil {
+		return GitDescribe{}, xerrors.Errorf("unable to build a version from %q: %w", s, err)
+	}
+	return d, nil
+}
+
+// buildVersion returns a version that is ordered after the tag and before the next release
+// in the same way as Go pseudo-versions.
+// e.g. 1.4.2-17-g3a9c1f2 => 1.4.3-0.17+g3a9c1f2, 1.4.2-rc.1-17-g3a9c1f2 => 1.4.2-rc.1.0.17+g3a9c1f2
+func (d GitDescribe) buildVersion() (Version, error) {
+	var metadata []string
+	if m := d.tag.Metadata(); m != "" {
+		metadata = append(metadata, m)
+	}
+	if d.hash != "" {
+		metadata = append(metadata, "g"+d.hash)
+	}
+	if d.dirty {
+		metadata = append(metadata, "dirty")
+	}
+
+	v := d.tag
+	if d.distance > 0 {
+		pre := "0." + strconv.FormatUint(d.distance, 10)
+		if v.IsPreRelease() {
+			pre = v.PreRelease().String() + "." + pre
+		} else {
+			var err error
+			if v, err = v.Bump(LevelPatch); err != nil {
+				return Version{}, err
+			}
+		}
+
+		var err error
+		if v, err = v.WithPreRelease(pre); err != nil {
+			return Version{}, err
+		}
+	}
+	return v.WithMetadata(strings.Join(metadata, "."))
+}
+
+// Tag returns the nearest tag.
+func (d GitDescribe) Tag() Version {
+	return d.tag
+}
+
+// Distance returns the number of commits since the tag.
+func (d GitDescribe) Distance() uint64 {
+	return d.distance
+}
+
+// Hash returns the abbreviated commit hash without the "g" prefix, or an empty string for an exact tag.
+func (d GitDescribe) Hash() string {
+	return d.hash
+}
+
+// Dirty returns true if the working tree has local modifications.
+func (d GitDescribe) Dirty() bool {
+	return d.dirty
+}
+
+// Version returns the version of the build, which is ordered after the tag and before the next release by Compare.
+// The commit hash and the dirty flag are stored in build metadata.
+// e.g. v1.4.2-17-g3a9c1f2-dirty => 1.4.3-0.17+g3a9c1f2.dirty
+func (d GitDescribe) Version() Version {
+	return d.version
+}
+
+// Original returns the original value.
+func (d GitDescribe) Original() string {
+	return d.original
+}
@@ -0,0 +1,120 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestParseGitDescribe(t *testing.T) {
+	tests := []struct {
+		input        string
+		wantTag      string
+		wantDistance uint64
+		wantHash     string
+		wantDirty    bool
+		wantVersion  string
+		wantErr      bool
+	}{
+		{
+			input:       "v1.4.2",
+			wantTag:     "1.4.2",
+			wantVersion: "1.4.2",
+		},
+		{
+			input:       "v1.4.2-dirty",
+			wantTag:     "1.4.2",
+			wantDirty:   true,
+			wantVersion: "1.4.2+dirty",
+		},
+		{
+			input:       "v1.4.2-0-g3a9c1f2",
+			wantTag:     "1.4.2",
+			wantHash:    "3a9c1f2",
+			wantVersion: "1.4.2+g3a9c1f2",
+		},
+		{
+			input:        "v1.4.2-17-g3a9c1f2",
+			wantTag:      "1.4.2",
+			wantDistance: 17,
+			wantHash:     "3a9c1f2",
+			wantVersion:  "1.4.3-0.17+g3a9c1f2",
+		},
+		{
+			input:        "1.4.2-17-g3a9c1f2-dirty",
+			wantTag:      "1.4.2",
+			wantDistance: 17,
+			wantHash:     "3a9c1f2",
+			wantDirty:    true,
+			wantVersion:  "1.4.3-0.17+g3a9c1f2.dirty",
+		},
+		{
+			input:        "v2.0.0-rc.1-3-gdeadbeef",
+			wantTag:      "2.0.0-rc.1",
+			wantDistance: 3,
+			wantHash:     "deadbeef",
+			wantVersion:  "2.0.0-rc.1.0.3+gdeadbeef",
+		},
+		{
+			input:        "v1.0.0+build.5-1-gabcd",
+			wantTag:      "1.0.0+build.5",
+			wantDistance: 1,
+			wantHash:     "abcd",
+			wantVersion:  "1.0.1-0.1+build.5.gabcd",
+		},
+		{input: "", wantErr: true},
+		{input: "release-17-g3a9c1f2", wantErr: true},
+		{input: "v1.4-17-g3a9c1f2", wantErr: true},
+		{input: "v1.4.2-99999999999999999999-g3a9c1f2", wantErr: true},
+		{input: "v1.4.18446744073709551615-1-g3a9c1f2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := semver.ParseGitDescribe(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTag, got.Tag().String())
+			assert.Equal(t, tt.wantDistance, got.Distance())
+			assert.Equal(t, tt.wantHash, got.Hash())
+			assert.Equal(t, tt.wantDirty, got.Dirty())
+			assert.Equal(t, tt.wantVersion, got.Version().String())
+			assert.Equal(t, tt.input, got.Original())
+		})
+	}
+}
+
+func TestGitDescribe_Version(t *testing.T) {
+	// Builds are ordered after their tag and before the next release
+	sorted := []string{
+		"v1.4.2-rc.1",
+		"v1.4.2-rc.1-3-gabcdef0",
+		"v1.4.2-rc.1-10-gabcdef0",
+		"v1.4.2-rc.2",
+		"v1.4.2",
+		"v1.4.2-1-gabcdef0",
+		"v1.4.2-17-g3a9c1f2",
+		"v1.4.2-18-g3a9c1f2",
+		"v1.4.3-alpha",
+		"v1.4.3",
+	}
+	for i := 0; i+1 < len(sorted); i++ {
+		v1, err := semver.ParseGitDescribe(sorted[i])
+		require.NoError(t, err)
+		v2, err := semver.ParseGitDescribe(sorted[i+1])
+		require.NoError(t, err)
+		assert.True(t, v1.Version().LessThan(v2.Version()), "%s < %s", sorted[i], sorted[i+1])
+	}
+
+	// The dirty flag and the hash don't affect the order
+	v1, err := semver.ParseGitDescribe("v1.4.2-17-g3a9c1f2-dirty")
+	require.NoError(t, err)
+	v2, err := semver.ParseGitDescribe("v1.4.2-17-g0000000")
+	require.NoError(t, err)
+	assert.True(t, v1.Version().Equal(v2.Version()))
+}
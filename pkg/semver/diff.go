@@ -0,0 +1,122 @@
+package semver
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+	"github.com/aquasecurity/go-version/pkg/prerelease"
+)
+
+// DiffKind represents the most significant part changed between two versions.
+type DiffKind int
+
+const (
+	DiffNone DiffKind = iota
+	DiffMetadata
+	DiffPreRelease
+	DiffPatch
+	DiffMinor
+	DiffMajor
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffNone:
+		return "none"
+	case DiffMetadata:
+		return "metadata"
+	case DiffPreRelease:
+		return "prerelease"
+	case DiffPatch:
+		return "patch"
+	case DiffMinor:
+		return "minor"
+	case DiffMajor:
+		return "major"
+	}
+	return fmt.Sprintf("DiffKind(%d)", int(k))
+}
+
+// Direction represents whether a change is an upgrade or a downgrade.
+type Direction int
+
+const (
+	Downgrade Direction = iota - 1
+	Unchanged
+	Upgrade
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Downgrade:
+		return "downgrade"
+	case Unchanged:
+		return "unchanged"
+	case Upgrade:
+		return "upgrade"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// Difference represents the change from one version to another.
+type Difference struct {
+	// Kind is the most significant part that differs.
+	Kind DiffKind
+
+	// Direction is Upgrade if the new version has higher precedence, Downgrade if lower,
+	// and Unchanged otherwise, e.g. only the build metadata differs.
+	Direction Direction
+
+	// Compatible is true if the change is not a downgrade and stays within the caret range
+	// of the old version, i.e. the major version for 1.0.0 and above,
+	// the minor version for 0.y.z and the patch version for 0.0.z are kept.
+	Compatible bool
+}
+
+// Diff classifies the change from a to b.
+// e.g. 1.2.3 => 1.3.0 is a compatible minor upgrade, while 0.2.3 => 0.3.0 is an incompatible one.
+// The zero Version is treated as 0.0.0.
+func Diff(a, b Version) Difference {
+	a, b = a.withZeroParts(), b.withZeroParts()
+	d := Difference{
+		Kind:      DiffNone,
+		Direction: Direction(b.Compare(a)),
+	}
+
+	switch {
+	case a.major.Compare(b.major) != 0:
+		d.Kind = DiffMajor
+	case a.minor.Compare(b.minor) != 0:
+		d.Kind = DiffMinor
+	case a.patch.Compare(b.patch) != 0:
+		d.Kind = DiffPatch
+	case prerelease.Compare(a.preRelease, b.preRelease) != 0:
+		d.Kind = DiffPreRelease
+	case a.buildMetadata != b.buildMetadata:
+		d.Kind = DiffMetadata
+	}
+
+	// The most significant part that caret ranges keep, like CaretBump
+	var significant DiffKind
+	switch {
+	case a.major != part.Zero:
+		significant = DiffMajor
+	case a.minor != part.Zero:
+		significant = DiffMinor
+	default:
+		significant = DiffPatch
+	}
+	d.Compatible = d.Direction != Downgrade && d.Kind < significant
+
+	return d
+}
+
+// withZeroParts returns a copy of the version with missing parts set to 0, e.g. the zero Version => 0.0.0
+func (v Version) withZeroParts() Version {
+	for _, p := range []*part.Part{&v.major, &v.minor, &v.patch} {
+		if *p == nil {
+			*p = part.Zero
+		}
+	}
+	return v
+}
@@ -0,0 +1,53 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want semver.Difference
+	}{
+		{a: "1.2.3", b: "1.2.3", want: semver.Difference{Kind: semver.DiffNone, Direction: semver.Unchanged, Compatible: true}},
+		{a: "1.2.3", b: "2.0.0", want: semver.Difference{Kind: semver.DiffMajor, Direction: semver.Upgrade}},
+		{a: "1.2.3", b: "1.3.0", want: semver.Difference{Kind: semver.DiffMinor, Direction: semver.Upgrade, Compatible: true}},
+		{a: "1.2.3", b: "1.2.4", want: semver.Difference{Kind: semver.DiffPatch, Direction: semver.Upgrade, Compatible: true}},
+		{a: "1.2.3", b: "1.2.2", want: semver.Difference{Kind: semver.DiffPatch, Direction: semver.Downgrade}},
+		{a: "1.2.3", b: "2.0.0-rc.1", want: semver.Difference{Kind: semver.DiffMajor, Direction: semver.Upgrade}},
+		{a: "1.2.3-rc.1", b: "1.2.3", want: semver.Difference{Kind: semver.DiffPreRelease, Direction: semver.Upgrade, Compatible: true}},
+		{a: "1.2.3", b: "1.2.3-rc.1", want: semver.Difference{Kind: semver.DiffPreRelease, Direction: semver.Downgrade}},
+		{a: "1.2.3+a", b: "1.2.3+b", want: semver.Difference{Kind: semver.DiffMetadata, Direction: semver.Unchanged, Compatible: true}},
+		{a: "0.2.3", b: "0.2.4", want: semver.Difference{Kind: semver.DiffPatch, Direction: semver.Upgrade, Compatible: true}},
+		{a: "0.2.3", b: "0.3.0", want: semver.Difference{Kind: semver.DiffMinor, Direction: semver.Upgrade}},
+		{a: "0.2.3", b: "1.0.0", want: semver.Difference{Kind: semver.DiffMajor, Direction: semver.Upgrade}},
+		{a: "0.0.3", b: "0.0.4", want: semver.Difference{Kind: semver.DiffPatch, Direction: semver.Upgrade}},
+		{a: "0.0.3-rc.1", b: "0.0.3", want: semver.Difference{Kind: semver.DiffPreRelease, Direction: semver.Upgrade, Compatible: true}},
+		{a: "0.0.3", b: "0.0.3+build", want: semver.Difference{Kind: semver.DiffMetadata, Direction: semver.Unchanged, Compatible: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" => "+tt.b, func(t *testing.T) {
+			got := semver.Diff(semver.MustParse(tt.a), semver.MustParse(tt.b))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("zero value", func(t *testing.T) {
+		assert.Equal(t, semver.Difference{Kind: semver.DiffNone, Direction: semver.Unchanged, Compatible: true},
+			semver.Diff(semver.Version{}, semver.Version{}))
+		assert.Equal(t, semver.Difference{Kind: semver.DiffMinor, Direction: semver.Upgrade},
+			semver.Diff(semver.Version{}, semver.MustParse("0.1.0")))
+		assert.Equal(t, semver.Difference{Kind: semver.DiffMajor, Direction: semver.Downgrade},
+			semver.Diff(semver.MustParse("1.2.3"), semver.Version{}))
+	})
+}
+
+func TestDiffKind_String(t *testing.T) {
+	assert.Equal(t, "major", semver.DiffMajor.String())
+	assert.Equal(t, "prerelease", semver.DiffPreRelease.String())
+	assert.Equal(t, "downgrade", semver.Downgrade.String())
+}
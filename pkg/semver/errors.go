@@ -0,0 +1,84 @@
+package semver
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// ErrInvalidSemVer is returned when a given version is invalid
+	ErrInvalidSemVer = xerrors.New("invalid semantic version")
+
+	// ErrInvalidConstraint is returned when a given constraint is invalid
+	ErrInvalidConstraint = xerrors.New("improper constraint")
+
+	// ErrNotNumeric is returned when a part to be incremented is not a number, e.g. a wildcard
+	ErrNotNumeric = xerrors.New("not a numeric version")
+
+	// ErrOverflow is returned when a part to be incremented is already the maximum value
+	ErrOverflow = xerrors.New("version overflow")
+)
+
+// ParseError describes why a version or constraint string could not be parsed.
+// It matches ErrInvalidSemVer or ErrInvalidConstraint with errors.Is.
+type ParseError struct {
+	// Input is the whole string being parsed.
+	Input string
+	// Offset is the byte offset in Input where the problem was found.
+	Offset int
+	// Component is the part of the input being parsed,
+	// e.g. "major", "prerelease", "buildmetadata", "operator" or "version".
+	Component string
+	// Reason is a human-readable description, e.g. "leading zero in minor".
+	Reason string
+	// Err is the underlying error, if any, e.g. *strconv.NumError on overflow.
+	Err error
+
+	kind error
+}
+
+func newVersionError(input string, offset int, component, reason string, err error) *ParseError {
+	return &ParseError{
+		Input:     input,
+		Offset:    offset,
+		Component: component,
+		Reason:    reason,
+		Err:       err,
+		kind:      ErrInvalidSemVer,
+	}
+}
+
+func newConstraintError(input string, offset int, component, reason string, err error) *ParseError {
+	return &ParseError{
+		Input:     input,
+		Offset:    offset,
+		Component: component,
+		Reason:    reason,
+		Err:       err,
+		kind:      ErrInvalidConstraint,
+	}
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%s %q: %s at offset %d", e.sentinel(), e.Input, e.Reason, e.Offset)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error and the underlying error, if any.
+func (e *ParseError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.sentinel()}
+	}
+	return []error{e.sentinel(), e.Err}
+}
+
+func (e *ParseError) sentinel() error {
+	if e.kind == nil {
+		return ErrInvalidSemVer
+	}
+	return e.kind
+}
@@ -0,0 +1,100 @@
+package semver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// e.g. 1.2.3 - 2.3.4
+	hyphenRegexp = regexp.MustCompile(`^\s*(\S+)\s+-\s+(\S+)\s*$`)
+
+	hyphenVersionRegexp = regexp.MustCompile(`^` + cvRegex + `$`)
+)
+
+// newHyphenConstraint parses a hyphen range such as "1.2.3 - 2.3.4" in segment,
+// which starts at offset in input, and expands it to comparators in the same way as npm.
+// ref. https://github.com/npm/node-semver/blob/v7.6.3/classes/range.js#L476-L511
+//
+//	1.2.3 - 2.3.4 := >=1.2.3 <=2.3.4
+//	1.2 - 2.3.4   := >=1.2.0 <=2.3.4
+//	1.2.3 - 2.3   := >=1.2.3 <2.4.0-0
+//	1.2.3 - 2     := >=1.2.3 <3.0.0-0
+//	* - 2         := <3.0.0-0
+func newHyphenConstraint(input string, offset int, segment string, loc []int, conf conf) (constraint, error) {
+	from, to := segment[loc[2]:loc[3]], segment[loc[4]:loc[5]]
+
+	fm := hyphenVersionRegexp.FindStringSubmatch(from)
+	if fm == nil {
+		return constraint{}, newConstraintError(input, offset+loc[2], "version", "invalid version in hyphen range", nil)
+	}
+	tm := hyphenVersionRegexp.FindStringSubmatch(to)
+	if tm == nil {
+		return constraint{}, newConstraintError(input, offset+loc[4], "version", "invalid version in hyphen range", nil)
+	}
+
+	var ss []string
+	fMajor, fMinor, fPatch := fm[1], strings.TrimPrefix(fm[2], "."), strings.TrimPrefix(fm[3], ".")
+	switch {
+	case isWildcard(fMajor):
+	case isWildcard(fMinor):
+		ss = append(ss, ">="+fMajor+".0.0")
+	case isWildcard(fPatch):
+		ss = append(ss, ">="+fMajor+"."+fMinor+".0")
+	default:
+		ss = append(ss, ">="+strings.TrimPrefix(from, "v"))
+	}
+
+	tMajor, tMinor, tPatch := tm[1], strings.TrimPrefix(tm[2], "."), strings.TrimPrefix(tm[3], ".")
+	switch {
+	case isWildcard(tMajor):
+	case isWildcard(tMinor):
+		major, err := increment(tMajor)
+		if err != nil {
+			return constraint{}, newConstraintError(input, offset+loc[4], "version", "invalid major version in hyphen range", err)
+		}
+		ss = append(ss, "<"+major+".0.0-0")
+	case isWildcard(tPatch):
+		minor, err := increment(tMinor)
+		if err != nil {
+			return constraint{}, newConstraintError(input, offset+loc[4], "version", "invalid minor version in hyphen range", err)
+		}
+		ss = append(ss, "<"+tMajor+"."+minor+".0-0")
+	default:
+		ss = append(ss, "<="+strings.TrimPrefix(to, "v"))
+	}
+
+	if len(ss) == 0 {
+		// * - *
+		ss = append(ss, "*")
+	}
+
+	c := constraint{
+		original: strings.TrimSpace(segment),
+	}
+	for _, s := range ss {
+		// The comparators are not a part of input, so an error points at the hyphen range
+		sc, err := newConstraint(s, 0, s, conf)
+		if err != nil {
+			return constraint{}, newConstraintError(input, offset+loc[2], "version", "invalid hyphen range", err)
+		}
+		c.expansion = append(c.expansion, sc)
+	}
+	return c, nil
+}
+
+func isWildcard(s string) bool {
+	return s == "" || s == "x" || s == "X" || s == "*"
+}
+
+// increment returns the decimal string incremented by 1.
+func increment(s string) (string, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return "", err
+	} else if n == ^uint64(0) {
+		return "", ErrOverflow
+	}
+	return strconv.FormatUint(n+1, 10), nil
+}
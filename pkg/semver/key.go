@@ -0,0 +1,39 @@
+package semver
+
+import (
+	"strings"
+)
+
+// Key is a comparable representation of a version which can be used as a map key.
+// Two keys are equal if and only if the versions are equal by Compare.
+type Key struct {
+	canonical string
+}
+
+// String returns the canonical form of the version.
+func (k Key) String() string {
+	return k.canonical
+}
+
+// Canonical returns the version string without build metadata, which is ignored by Compare.
+// Two versions without wildcards have the same canonical form if and only if Compare returns 0.
+// e.g. v1.2.3-rc.1+build.5 => 1.2.3-rc.1
+// A pre-release compared lexically only with WithSemVer1(true) is quoted, e.g. 1.0.0-'9',
+// since 1.0.0-9 parsed without the option is compared numerically.
+func (v Version) Canonical() string {
+	if v.lexicalPreRelease() {
+		return v.Release().Canonical() + "-'" + v.preRelease[0].String() + "'"
+	}
+
+	s := v.String()
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// Key returns a comparable key of the version.
+// e.g. 1.0.0+a and 1.0.0+b have the same key.
+func (v Version) Key() Key {
+	return Key{canonical: v.Canonical()}
+}
@@ -0,0 +1,65 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestVersion_Canonical(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.2.3", want: "1.2.3"},
+		{version: "v1.2.3", want: "1.2.3"},
+		{version: "1.2.3+build.5", want: "1.2.3"},
+		{version: "1.2.3-rc.1+build.5", want: "1.2.3-rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := semver.Parse(tt.version, semver.WithVPrefix(true))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v.Canonical())
+			assert.Equal(t, tt.want, v.Key().String())
+		})
+	}
+}
+
+func TestVersion_Key(t *testing.T) {
+	corpus := []string{
+		"1.2.3", "v1.2.3", "1.2.3+a", "1.2.3+b", "1.2.4", "1.2.3-rc.1", "1.2.3-rc.1+build",
+		"1.2.3-rc.2", "1.2.3-rc.1.0", "1.2.3-1", "1.2.3-a", "0.0.0", "18446744073709551615.0.0",
+		"1.0.0-18446744073709551616", "1.0.0-18446744073709551617",
+	}
+	// A numeric pre-release identifier is compared lexically with WithSemVer1(true)
+	semVer1Corpus := []string{"1.2.3", "1.0.0-9", "1.0.0-10", "1.2.3-rc1", "1.0.0-18446744073709551616"}
+
+	var versions []semver.Version
+	for _, s := range corpus {
+		v, err := semver.Parse(s, semver.WithVPrefix(true))
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+	for _, s := range semVer1Corpus {
+		v, err := semver.Parse(s, semver.WithSemVer1(true))
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+
+	seen := make(map[semver.Key]semver.Version)
+	for _, v1 := range versions {
+		for _, v2 := range versions {
+			assert.Equal(t, v1.Compare(v2) == 0, v1.Key() == v2.Key(), "%s and %s", v1.Original(), v2.Original())
+		}
+		seen[v1.Key()] = v1
+	}
+	assert.Len(t, seen, len(corpus)-4+3)
+
+	v1, v2 := semver.MustParse("1.0.0-9", semver.WithSemVer1(true)), semver.MustParse("1.0.0-9")
+	assert.Equal(t, "1.0.0-'9'", v1.Canonical())
+	assert.NotEqual(t, v1.Canonical(), v2.Canonical())
+}
@@ -0,0 +1,104 @@
+package semver
+
+import (
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/internal/codec"
+)
+
+// binarySemVer1 is the flag of the MarshalBinary output for a version which must be parsed with WithSemVer1(true).
+const binarySemVer1 byte = 1
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns String(), so pre-release and build metadata are preserved, but Original() is not.
+// The zero Version is encoded as an empty text.
+// It returns an error if the pre-release can only be parsed with WithSemVer1(true), e.g. 1.0.0-9,
+// since the text is parsed without options. Use MarshalBinary for such versions.
+func (v Version) MarshalText() ([]byte, error) {
+	if v.lexicalPreRelease() {
+		return nil, xerrors.Errorf("%s cannot be marshaled as text: the pre-release is parsed only with WithSemVer1(true)", v)
+	}
+	return []byte(v.text()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// An empty text decodes to the zero Version.
+func (v *Version) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = Version{}
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A version is encoded as a JSON string.
+// It returns an error in the same cases as MarshalText.
+func (v Version) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null leaves the version unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return xerrors.Errorf("version must be a JSON string: %w", err)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Unlike MarshalText, it also preserves Original() and whether the version is parsed with WithSemVer1(true).
+func (v Version) MarshalBinary() ([]byte, error) {
+	var flag byte
+	if v.lexicalPreRelease() {
+		flag = binarySemVer1
+	}
+
+	return codec.MarshalBinary(flag, v.text(), v.original), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Version) UnmarshalBinary(data []byte) error {
+	flag, ss, err := codec.UnmarshalBinary(data, 2)
+	if err != nil {
+		return err
+	} else if flag > binarySemVer1 {
+		return xerrors.New("unknown binary version flag")
+	}
+
+	if ss[0] == "" {
+		*v = Version{}
+		return nil
+	}
+	parsed, err := Parse(ss[0], WithSemVer1(flag == binarySemVer1))
+	if err != nil {
+		return err
+	}
+	parsed.original = ss[1]
+	*v = parsed
+	return nil
+}
+
+// text returns String(), or an empty string for the zero Version.
+func (v Version) text() string {
+	if v.major == nil {
+		return ""
+	}
+	return v.String()
+}
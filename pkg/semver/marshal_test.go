@@ -0,0 +1,186 @@
+package semver_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestVersion_MarshalJSON(t *testing.T) {
+	type pkg struct {
+		Name    string          `json:"name"`
+		Version semver.Version  `json:"version"`
+		Fixed   *semver.Version `json:"fixed,omitempty"`
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{
+			name:    "release",
+			version: "1.2.3",
+			want:    `{"name":"foo","version":"1.2.3"}`,
+		},
+		{
+			name:    "pre-release and metadata",
+			version: "1.2.3-alpha.1+build.5",
+			want:    `{"name":"foo","version":"1.2.3-alpha.1+build.5"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := semver.Parse(tt.version)
+			require.NoError(t, err)
+
+			b, err := json.Marshal(pkg{Name: "foo", Version: v})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(b))
+
+			var got pkg
+			require.NoError(t, json.Unmarshal(b, &got))
+			assert.Equal(t, v, got.Version)
+			assert.Equal(t, tt.version, got.Version.Original())
+			assert.Nil(t, got.Fixed)
+		})
+	}
+}
+
+func TestVersion_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "string", input: `"1.2.3-beta"`, want: "1.2.3-beta"},
+		{name: "null", input: `null`},
+		{name: "empty", input: `""`},
+		{name: "invalid version", input: `"1.2"`, wantErr: true},
+		{name: "number", input: `1.2`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got semver.Version
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Original())
+		})
+	}
+}
+
+func TestVersion_MarshalText(t *testing.T) {
+	v, err := semver.Parse("v1.2.3-rc.1+sha.5114f85", semver.WithVPrefix(true))
+	require.NoError(t, err)
+
+	b, err := v.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3-rc.1+sha.5114f85", string(b))
+
+	var got semver.Version
+	require.NoError(t, got.UnmarshalText(b))
+	assert.Equal(t, 0, v.Compare(got))
+	assert.Equal(t, v.Metadata(), got.Metadata())
+	assert.Equal(t, "1.2.3-rc.1+sha.5114f85", got.Original())
+
+	b, err = semver.Version{}.MarshalText()
+	require.NoError(t, err)
+	assert.Empty(t, b)
+}
+
+func TestVersion_MarshalSemVer1(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		v, err := semver.Parse("1.0.0-rc1", semver.WithSemVer1(true))
+		require.NoError(t, err)
+
+		text, err := v.MarshalText()
+		require.NoError(t, err)
+		var fromText semver.Version
+		require.NoError(t, fromText.UnmarshalText(text))
+		assert.Equal(t, 0, v.Compare(fromText))
+
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		var fromJSON semver.Version
+		require.NoError(t, json.Unmarshal(b, &fromJSON))
+		assert.Equal(t, 0, v.Compare(fromJSON))
+
+		b, err = v.MarshalBinary()
+		require.NoError(t, err)
+		var fromBinary semver.Version
+		require.NoError(t, fromBinary.UnmarshalBinary(b))
+		assert.Equal(t, v, fromBinary)
+	})
+
+	t.Run("lexical pre-release", func(t *testing.T) {
+		// 1.0.0-9 is greater than 1.0.0-10 only with WithSemVer1(true), and 1.0.0-01 is invalid without it
+		var versions []semver.Version
+		for _, s := range []string{"1.0.0-01", "1.0.0-10", "1.0.0-9", "1.0.0-x"} {
+			v, err := semver.Parse(s, semver.WithSemVer1(true))
+			require.NoError(t, err)
+			versions = append(versions, v)
+
+			_, err = v.MarshalText()
+			assert.Error(t, err, s)
+			_, err = json.Marshal(v)
+			assert.Error(t, err, s)
+		}
+
+		var got []semver.Version
+		for _, v := range versions {
+			b, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			var u semver.Version
+			require.NoError(t, u.UnmarshalBinary(b))
+			assert.Equal(t, v, u)
+			got = append(got, u)
+		}
+		for i := 1; i < len(got); i++ {
+			assert.Equal(t, -1, got[i-1].Compare(got[i]), "%s < %s", got[i-1], got[i])
+		}
+	})
+}
+
+func TestVersion_MarshalBinary(t *testing.T) {
+	for _, s := range []string{"1.2.3", "v1.2.3-rc.1+sha.5114f85", "v0.0.0-y.7.z.92"} {
+		t.Run(s, func(t *testing.T) {
+			v, err := semver.Parse(s, semver.WithVPrefix(true))
+			require.NoError(t, err)
+
+			b, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			var got semver.Version
+			require.NoError(t, got.UnmarshalBinary(b))
+			assert.Equal(t, v, got)
+			assert.Equal(t, s, got.Original())
+		})
+	}
+
+	t.Run("zero", func(t *testing.T) {
+		b, err := semver.Version{}.MarshalBinary()
+		require.NoError(t, err)
+
+		got, err := semver.Parse("1.2.3")
+		require.NoError(t, err)
+		require.NoError(t, got.UnmarshalBinary(b))
+		assert.Equal(t, semver.Version{}, got)
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		for _, b := range [][]byte{nil, {0}, {1}, {1, 2}, {1, 0, 5, '1'}, {1, 0, 0, 0, 0}} {
+			var got semver.Version
+			assert.Error(t, got.UnmarshalBinary(b), b)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package semver
+
+type parseConf struct {
+	vPrefix                  bool
+	semVer1                  bool
+	maxLength                int
+	maxPreReleaseIdentifiers int
+}
+
+func newParseConf(opts []ParseOption) parseConf {
+	var c parseConf
+	for _, o := range opts {
+		o.applyParse(&c)
+	}
+	return c
+}
+
+// ParseOption configures Parse, e.g. WithVPrefix(true).
+type ParseOption interface {
+	applyParse(*parseConf)
+}
+
+// WithVPrefix allows a leading "v" such as "v1.2.3".
+// The prefix is kept in Original(), but not in String().
+type WithVPrefix bool
+
+func (o WithVPrefix) applyParse(c *parseConf) {
+	c.vPrefix = bool(o)
+}
+
+// WithSemVer1 parses versions according to Semantic Versioning 1.0.0.
+// The pre-release is a single identifier compared in ASCII sort order,
+// and build metadata is not allowed.
+// ref. https://semver.org/spec/v1.0.0.html
+type WithSemVer1 bool
+
+func (o WithSemVer1) applyParse(c *parseConf) {
+	c.semVer1 = bool(o)
+}
+
+// WithMaxLength rejects versions longer than the given number of bytes
+// before scanning them. Zero means no limit.
+type WithMaxLength int
+
+func (o WithMaxLength) applyParse(c *parseConf) {
+	c.maxLength = int(o)
+}
+
+// WithMaxPreReleaseIdentifiers rejects versions with more than the given number
+// of dot-separated pre-release identifiers. Zero means no limit.
+type WithMaxPreReleaseIdentifiers int
+
+func (o WithMaxPreReleaseIdentifiers) applyParse(c *parseConf) {
+	c.maxPreReleaseIdentifiers = int(o)
+}
@@ -0,0 +1,208 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// parse scans a SemVer string by hand. It accepts exactly the language of
+// the regular expression suggested by the specification.
+// See: https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
+// The options may relax or tighten the language, e.g. WithVPrefix and WithMaxLength.
+func parse(s string, c parseConf) (Version, error) {
+	switch {
+	case s == "":
+		return Version{}, newVersionError(s, 0, "major", "empty version", nil)
+	case c.maxLength > 0 && len(s) > c.maxLength:
+		return Version{}, newVersionError(s, c.maxLength, "version", fmt.Sprintf("longer than %d bytes", c.maxLength), nil)
+	}
+
+	begin := 0
+	if c.vPrefix && s[0] == 'v' {
+		begin = 1
+	}
+
+	// MAJOR.MINOR.PATCH
+	majorEnd, err := scanNumeric(s, begin, "major")
+	if err != nil {
+		return Version{}, err
+	}
+	if err = expectDot(s, majorEnd, "major"); err != nil {
+		return Version{}, err
+	}
+	minorEnd, err := scanNumeric(s, majorEnd+1, "minor")
+	if err != nil {
+		return Version{}, err
+	}
+	if err = expectDot(s, minorEnd, "minor"); err != nil {
+		return Version{}, err
+	}
+	patchEnd, err := scanNumeric(s, minorEnd+1, "patch")
+	if err != nil {
+		return Version{}, err
+	}
+
+	// -PRERELEASE
+	i, component := patchEnd, "patch"
+	var pre part.Parts
+	switch {
+	case i < len(s) && s[i] == '-' && c.semVer1:
+		// A single identifier compared lexically
+		start := i + 1
+		i = start
+		for i < len(s) && isIdentifierChar(s[i]) {
+			i++
+		}
+		if i == start {
+			return Version{}, newVersionError(s, i, "prerelease", "empty prerelease identifier", nil)
+		}
+		pre = part.Parts{part.NewString(s[start:i])}
+		component = "prerelease"
+	case i < len(s) && s[i] == '-':
+		start := i + 1
+		if i, err = scanIdentifiers(s, start, "prerelease", true); err != nil {
+			return Version{}, err
+		}
+		if n := c.maxPreReleaseIdentifiers; n > 0 {
+			if offset := identifierOffset(s[start:i], n); offset >= 0 {
+				return Version{}, newVersionError(s, start+offset, "prerelease",
+					fmt.Sprintf("more than %d prerelease identifiers", n), nil)
+			}
+		}
+		pre = part.NewParts(s[start:i])
+		component = "prerelease"
+	}
+
+	// +BUILDMETADATA
+	var metadata string
+	if i < len(s) && s[i] == '+' && !c.semVer1 {
+		start := i + 1
+		if i, err = scanIdentifiers(s, start, "buildmetadata", false); err != nil {
+			return Version{}, err
+		}
+		metadata = s[start:i]
+		component = "buildmetadata"
+	}
+
+	if i != len(s) {
+		return Version{}, newVersionError(s, i, component, fmt.Sprintf("unexpected character %q", s[i]), nil)
+	}
+
+	major, err := parseUint64(s, begin, majorEnd, "major")
+	if err != nil {
+		return Version{}, err
+	}
+	minor, err := parseUint64(s, majorEnd+1, minorEnd, "minor")
+	if err != nil {
+		return Version{}, err
+	}
+	patch, err := parseUint64(s, minorEnd+1, patchEnd, "patch")
+	if err != nil {
+		return Version{}, err
+	}
+
+	return Version{
+		major:         major,
+		minor:         minor,
+		patch:         patch,
+		preRelease:    pre,
+		buildMetadata: metadata,
+		original:      s,
+	}, nil
+}
+
+// scanNumeric scans a numeric identifier without leading zeros starting at i
+// and returns the index just past it.
+func scanNumeric(s string, i int, component string) (int, error) {
+	start := i
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	switch {
+	case i == start && i == len(s):
+		return i, newVersionError(s, i, component, "missing "+component, nil)
+	case i == start:
+		return i, newVersionError(s, i, component, fmt.Sprintf("unexpected character %q in %s", s[i], component), nil)
+	case s[start] == '0' && i-start > 1:
+		return i, newVersionError(s, start, component, "leading zero in "+component, nil)
+	}
+	return i, nil
+}
+
+func expectDot(s string, i int, component string) error {
+	switch {
+	case i == len(s):
+		return newVersionError(s, i, component, "expected '.' after "+component, nil)
+	case s[i] != '.':
+		return newVersionError(s, i, component, fmt.Sprintf("unexpected character %q in %s", s[i], component), nil)
+	}
+	return nil
+}
+
+// scanIdentifiers scans dot-separated identifiers starting at i and returns
+// the index just past the last one. Numeric identifiers must not include
+// leading zeros if strict is true, as required for pre-release versions.
+func scanIdentifiers(s string, i int, component string, strict bool) (int, error) {
+	for {
+		start := i
+		numeric := true
+		for i < len(s) && isIdentifierChar(s[i]) {
+			numeric = numeric && isDigit(s[i])
+			i++
+		}
+		switch {
+		case i == start:
+			return i, newVersionError(s, i, component, fmt.Sprintf("empty %s identifier", component), nil)
+		case strict && numeric && s[start] == '0' && i-start > 1:
+			return i, newVersionError(s, start, component, fmt.Sprintf("leading zero in %s identifier", component), nil)
+		}
+
+		if i == len(s) || s[i] != '.' {
+			return i, nil
+		}
+		i++
+	}
+}
+
+// validateIdentifiers checks that the whole s is dot-separated identifiers.
+func validateIdentifiers(s, component string, strict bool) error {
+	i, err := scanIdentifiers(s, 0, component, strict)
+	if err != nil {
+		return err
+	} else if i != len(s) {
+		return newVersionError(s, i, component, fmt.Sprintf("unexpected character %q in %s", s[i], component), nil)
+	}
+	return nil
+}
+
+// identifierOffset returns the offset of the n-th (0-origin) dot-separated identifier in s,
+// or -1 if s has no more than n identifiers.
+func identifierOffset(s string, n int) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '.' {
+			continue
+		}
+		if n--; n == 0 {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func parseUint64(s string, start, end int, component string) (part.Uint64, error) {
+	n, err := strconv.ParseUint(s[start:end], 10, 64)
+	if err != nil {
+		return 0, newVersionError(s, start, component, fmt.Sprintf("invalid %s version", component), err)
+	}
+	return part.Uint64(n), nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentifierChar(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '-'
+}
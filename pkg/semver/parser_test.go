@@ -0,0 +1,148 @@
+package semver
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// referenceRegex is the regular expression Parse used before the hand-written scanner.
+// It is kept here as the reference implementation for the differential tests.
+var referenceRegex = regexp.MustCompile(`^(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)` +
+	`(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))` +
+	`?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+func parseWithRegex(v string) (Version, error) {
+	m := referenceRegex.FindStringSubmatch(v)
+	if m == nil {
+		return Version{}, ErrInvalidSemVer
+	}
+
+	major, err := part.NewUint64(m[referenceRegex.SubexpIndex("major")])
+	if err != nil {
+		return Version{}, xerrors.Errorf("invalid major version: %w", err)
+	}
+
+	minor, err := part.NewUint64(m[referenceRegex.SubexpIndex("minor")])
+	if err != nil {
+		return Version{}, xerrors.Errorf("invalid minor version: %w", err)
+	}
+
+	patch, err := part.NewUint64(m[referenceRegex.SubexpIndex("patch")])
+	if err != nil {
+		return Version{}, xerrors.Errorf("invalid patch version: %w", err)
+	}
+
+	return Version{
+		major:         major,
+		minor:         minor,
+		patch:         patch,
+		preRelease:    part.NewParts(m[referenceRegex.SubexpIndex("prerelease")]),
+		buildMetadata: m[referenceRegex.SubexpIndex("buildmetadata")],
+		original:      v,
+	}, nil
+}
+
+var parseCorpus = []string{
+	"",
+	"1.2.3",
+	"0.0.0",
+	"01.2.3",
+	"1.02.3",
+	"1.2.03",
+	"1.2.3-alpha.01",
+	"1.2.3-alpha.0",
+	"1.2.3-alpha.0a",
+	"1.2.3-01a",
+	"1.2.3+test.01",
+	"1.2.3-alpha.-1",
+	"1.2.3-",
+	"1.2.3+",
+	"1.2.3-a..b",
+	"1.2.3-a.",
+	"1.2.3+a..b",
+	"1.2.3-x.X.*",
+	"1.0",
+	"1",
+	"1.2.beta",
+	"foo",
+	"\n1.2.3",
+	"1.2.3\n",
+	"1.2.0-x.Y.0+metadata-width-hypen",
+	"1.2.3-rc1-with-hypen",
+	"1.2.3.4",
+	"1.2.18446744073709551615",
+	"1.2.18446744073709551616",
+	"18446744073709551616.0.0",
+	"1.18446744073709551616.0",
+	"1.2.3-18446744073709551616",
+	"1.0.0-x-y-z.-",
+	"1.0.0-alpha+001+002",
+	"v1.2.3",
+	"1.2.3-ünicode",
+}
+
+func TestParse_Reference(t *testing.T) {
+	for _, tt := range parseCorpus {
+		t.Run(tt, func(t *testing.T) {
+			want, wantErr := parseWithRegex(tt)
+			got, gotErr := Parse(tt)
+			if wantErr != nil {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range parseCorpus {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want, wantErr := parseWithRegex(s)
+		got, gotErr := Parse(s)
+		if (wantErr != nil) != (gotErr != nil) {
+			t.Fatalf("Parse(%q): got error %v, want %v", s, gotErr, wantErr)
+		}
+		if wantErr == nil {
+			assert.Equal(t, want, got)
+		}
+	})
+}
+
+var benchmarkVersions = []string{
+	"1.2.3",
+	"10.20.30-alpha.1+build.5",
+	"1.0.0-x.7.z.92",
+	"2.3.5-20161202202307-sha.e8fc5e5",
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkVersions {
+			if _, err := Parse(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkParse_Regex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkVersions {
+			if _, err := parseWithRegex(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package semver
+
+type preReleaseConf struct {
+	identifier string
+	base       uint64
+	noBase     bool
+}
+
+type PreReleaseOption interface {
+	applyPreRelease(*preReleaseConf)
+}
+
+// WithIdentifier sets the pre-release identifier such as "rc" for the pre-release increments.
+// e.g. 1.2.3 => 1.2.4-rc.0
+type WithIdentifier string
+
+func (o WithIdentifier) applyPreRelease(c *preReleaseConf) {
+	c.identifier = string(o)
+}
+
+// WithIdentifierBase sets the number the pre-release starts from. The default is 0.
+// e.g. 1.2.3 => 1.2.4-rc.1 with WithIdentifierBase(1)
+type WithIdentifierBase uint64
+
+func (o WithIdentifierBase) applyPreRelease(c *preReleaseConf) {
+	c.base = uint64(o)
+}
+
+// WithoutIdentifierBase omits the number after the identifier, like `identifierBase: false` in npm.
+// e.g. 1.2.3 => 1.2.4-rc
+type WithoutIdentifierBase bool
+
+func (o WithoutIdentifierBase) applyPreRelease(c *preReleaseConf) {
+	c.noBase = bool(o)
+}
@@ -0,0 +1,383 @@
+package semver
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+var (
+	negativeInfinity = Version{
+		major:      part.NegativeInfinity,
+		minor:      part.Zero,
+		patch:      part.Zero,
+		preRelease: part.Parts{},
+	}
+	// the minimum version
+	zero = Version{
+		major:      part.Zero,
+		minor:      part.Zero,
+		patch:      part.Zero,
+		preRelease: part.NewParts("0"),
+		original:   "0.0.0-0",
+	}
+	infinity = Version{
+		major:      part.Infinity,
+		minor:      part.Zero,
+		patch:      part.Zero,
+		preRelease: part.Parts{},
+	}
+)
+
+// Bound is the lower or upper end of a Range.
+// An open end is represented by a version with part.NegativeInfinity or part.Infinity as the major version.
+type Bound struct {
+	version   Version
+	inclusive bool
+}
+
+// Version returns the version of the bound.
+func (b Bound) Version() Version {
+	return b.version
+}
+
+// Inclusive returns true if the version of the bound is in the range.
+func (b Bound) Inclusive() bool {
+	return b.inclusive
+}
+
+// IsInfinite returns true if the bound is an open end.
+func (b Bound) IsInfinite() bool {
+	switch b.version.major.(type) {
+	case part.InfinityType, part.NegativeInfinityType:
+		return true
+	}
+	return false
+}
+
+// String returns "-∞" or "∞" for an open end, otherwise the version.
+func (b Bound) String() string {
+	if b.IsInfinite() {
+		return b.version.major.String()
+	}
+	return b.version.String()
+}
+
+// flip returns the bound on the other side of the same version, e.g. "<1.2.3" => ">=1.2.3".
+func (b Bound) flip() Bound {
+	b.inclusive = !b.inclusive
+	return b
+}
+
+// Range is an interval of versions between the lower and upper bounds.
+type Range struct {
+	lower Bound
+	upper Bound
+}
+
+// newRange returns a range between lower and upper, and false if no version is in the range.
+func newRange(lower, upper Bound) (Range, bool) {
+	if upper.version.Compare(zero) == 0 && !upper.inclusive {
+		// <0.0.0-0
+		return Range{}, false
+	}
+	c := lower.version.Compare(upper.version)
+	return Range{lower: lower, upper: upper}, c < 0 || (c == 0 && lower.inclusive && upper.inclusive)
+}
+
+// Lower returns the lower bound.
+func (r Range) Lower() Bound {
+	return r.lower
+}
+
+// Upper returns the upper bound.
+func (r Range) Upper() Bound {
+	return r.upper
+}
+
+// Contains tests if a version is in the range.
+func (r Range) Contains(v Version) bool {
+	if c := r.lower.version.Compare(v); c > 0 || (c == 0 && !r.lower.inclusive) {
+		return false
+	}
+	c := v.Compare(r.upper.version)
+	return c < 0 || (c == 0 && r.upper.inclusive)
+}
+
+// String returns the range in the form of ">=a <b", "=a" for a single version, or "*" for all versions.
+func (r Range) String() string {
+	var ss []string
+	switch {
+	case r.lower.IsInfinite() && r.upper.IsInfinite():
+		return "*"
+	case r.lower.version.Compare(r.upper.version) == 0:
+		return "=" + r.lower.String()
+	}
+
+	if !r.lower.IsInfinite() {
+		op := ">"
+		if r.lower.inclusive {
+			op = ">="
+		}
+		ss = append(ss, op+r.lower.String())
+	}
+	if !r.upper.IsInfinite() {
+		op := "<"
+		if r.upper.inclusive {
+			op = "<="
+		}
+		ss = append(ss, op+r.upper.String())
+	}
+	return strings.Join(ss, " ")
+}
+
+// Ranges is a sorted list of disjoint ranges. Adjacent ranges such as "<1.0.0" and ">=1.0.0" are merged.
+type Ranges []Range
+
+// Contains tests if a version is in one of the ranges.
+func (rs Ranges) Contains(v Version) bool {
+	for _, r := range rs {
+		if r.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the ranges separated by "||" like Constraints, or "<0.0.0-0" if no version is in the ranges.
+func (rs Ranges) String() string {
+	if len(rs) == 0 {
+		return "<" + zero.String()
+	}
+
+	ss := make([]string, len(rs))
+	for i, r := range rs {
+		ss[i] = r.String()
+	}
+	return strings.Join(ss, "||")
+}
+
+// RangesIncludingPreReleases compiles the constraints into a normalized set of ranges
+// which contain the versions satisfying the constraints with WithPreRelease(true), whatever the options are.
+// Pre-release versions are ordered as Compare does.
+// e.g. "^1.2 || ~2.3.4, != 2.3.5" => ">=1.2.0-0 <2.0.0||>=2.3.4 <2.3.5||>2.3.5 <2.4.0"
+func (cs Constraints) RangesIncludingPreReleases() Ranges {
+	var rs Ranges
+	for _, andCs := range cs.constraints {
+		rs = append(rs, andRanges(andCs)...)
+	}
+	return normalizeRanges(rs)
+}
+
+func andRanges(constraints []constraint) Ranges {
+	rs := Ranges{{lower: Bound{version: negativeInfinity}, upper: Bound{version: infinity}}}
+	for _, c := range constraints {
+		rs = intersectRanges(rs, c.ranges())
+	}
+	return rs
+}
+
+// ranges returns the versions which satisfy the constraint without the pre-release check.
+func (c constraint) ranges() Ranges {
+	if c.expansion != nil {
+		return andRanges(c.expansion)
+	}
+
+	// A wildcard with a pre-release never matches, see preCheck
+	if !c.version.preRelease.IsNull() && c.version.IsAny() {
+		return nil
+	}
+
+	s := span(c.version)
+	switch c.op {
+	case OpEqual:
+		return Ranges{s}
+	case OpNotEqual:
+		return complementRanges(Ranges{s})
+	case OpGreaterThan:
+		return atLeast(s.upper.flip())
+	case OpGreaterThanOrEqual:
+		return atLeast(s.lower)
+	case OpLessThan:
+		return lessThan(c.version.Min())
+	case OpLessThanOrEqual:
+		return atMost(s.upper)
+	case OpTilde, OpCaret:
+		if c.version.major.IsAny() {
+			// ~* and ^* match any version
+			return Ranges{s}
+		}
+		upper := c.version.TildeBump()
+		if c.op == OpCaret {
+			upper = c.version.CaretBump()
+		}
+		return intersectRanges(atLeast(s.lower), lessThan(upper))
+	}
+	return nil
+}
+
+// span returns the range of versions which are equal to c by Compare.
+// e.g. 1.2.3 => =1.2.3, 1.2.x => >=1.2.0-0 <1.3.0-0, 1.2.3-x => >=1.2.3-0 <=1.2.3
+func span(c Version) Range {
+	parts := []part.Part{c.major, c.minor, c.patch}
+	for i, p := range parts {
+		if !p.IsAny() {
+			continue
+		}
+		if i == 0 {
+			return Range{lower: Bound{version: negativeInfinity}, upper: Bound{version: infinity}}
+		}
+
+		lower := Version{major: part.Zero, minor: part.Zero, patch: part.Zero, preRelease: part.NewParts("0")}
+		for j, pp := range []*part.Part{&lower.major, &lower.minor}[:i] {
+			*pp = numeric(parts[j])
+		}
+		lower.original = lower.String()
+
+		// e.g. 1.2 => 1.3.0-0, 1.MAX => 2.0.0-0, MAX => ∞
+		upper := lower.bump(Level(i - 1))
+		if upper.major != part.Infinity {
+			upper.preRelease = part.NewParts("0")
+			upper.original = upper.String()
+		}
+		return Range{lower: Bound{version: lower, inclusive: true}, upper: Bound{version: upper}}
+	}
+
+	v := Version{
+		major:      numeric(c.major),
+		minor:      numeric(c.minor),
+		patch:      numeric(c.patch),
+		preRelease: c.preRelease,
+	}
+	if c.preRelease.IsAny() {
+		// The pre-release matches any pre-release and the release version.
+		// It's reached only from a constraint such as "1.2.3-x" since "x" and "X" in the pre-release
+		// of a constraint are still wildcards while "*" is rejected by the constraint syntax.
+		// The "*" constraint, including the one built by Range.constraints, returns above as its major is a wildcard.
+		v.preRelease = part.Parts{}
+		v.original = v.String()
+		lower := v
+		lower.preRelease = part.NewParts("0")
+		lower.original = lower.String()
+		return Range{lower: Bound{version: lower, inclusive: true}, upper: Bound{version: v, inclusive: true}}
+	}
+	v.original = v.String()
+	return Range{lower: Bound{version: v, inclusive: true}, upper: Bound{version: v, inclusive: true}}
+}
+
+// numeric replaces a missing part with zero.
+func numeric(p part.Part) part.Part {
+	if p.IsEmpty() {
+		return part.Zero
+	}
+	return p
+}
+
+// atLeast returns the versions greater than (or equal to) the lower bound.
+func atLeast(lower Bound) Ranges {
+	if r, ok := newRange(lower, Bound{version: infinity}); ok {
+		return Ranges{r}
+	}
+	return nil
+}
+
+// atMost returns the versions less than (or equal to) the upper bound.
+func atMost(upper Bound) Ranges {
+	if r, ok := newRange(Bound{version: negativeInfinity}, upper); ok {
+		return Ranges{r}
+	}
+	return nil
+}
+
+// lessThan returns the versions less than c by Compare.
+func lessThan(c Version) Ranges {
+	return atMost(span(c).lower.flip())
+}
+
+func compareLower(a, b Bound) int {
+	if c := a.version.Compare(b.version); c != 0 {
+		return c
+	}
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return -1
+	}
+	return 1
+}
+
+func compareUpper(a, b Bound) int {
+	if c := a.version.Compare(b.version); c != 0 {
+		return c
+	}
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return 1
+	}
+	return -1
+}
+
+// normalizeRanges sorts the ranges by the lower bounds and merges overlapping and adjacent ones.
+func normalizeRanges(rs Ranges) Ranges {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(rs)
+	slices.SortStableFunc(sorted, func(a, b Range) int {
+		return compareLower(a.lower, b.lower)
+	})
+
+	merged := Ranges{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if c := r.lower.version.Compare(last.upper.version); c > 0 || (c == 0 && !r.lower.inclusive && !last.upper.inclusive) {
+			merged = append(merged, r)
+			continue
+		}
+		if compareUpper(r.upper, last.upper) > 0 {
+			last.upper = r.upper
+		}
+	}
+	return merged
+}
+
+// intersectRanges returns the versions in both ranges.
+func intersectRanges(rs1, rs2 Ranges) Ranges {
+	var rs Ranges
+	for _, r1 := range rs1 {
+		for _, r2 := range rs2 {
+			lower, upper := r1.lower, r1.upper
+			if compareLower(r2.lower, lower) > 0 {
+				lower = r2.lower
+			}
+			if compareUpper(r2.upper, upper) < 0 {
+				upper = r2.upper
+			}
+			if r, ok := newRange(lower, upper); ok {
+				rs = append(rs, r)
+			}
+		}
+	}
+	return normalizeRanges(rs)
+}
+
+// complementRanges returns the versions not in the ranges.
+func complementRanges(rs Ranges) Ranges {
+	var complement Ranges
+	lower := Bound{version: negativeInfinity}
+	for _, r := range normalizeRanges(rs) {
+		if c, ok := newRange(lower, r.lower.flip()); ok {
+			complement = append(complement, c)
+		}
+		lower = r.upper.flip()
+	}
+	if c, ok := newRange(lower, Bound{version: infinity}); ok {
+		complement = append(complement, c)
+	}
+	return complement
+}
@@ -0,0 +1,138 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestConstraints_RangesIncludingPreReleases(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       []semver.ConstraintOption
+		want       string
+	}{
+		{constraint: "*", want: "*"},
+		{constraint: "", want: "*"},
+		{constraint: "1.2.3", want: "=1.2.3"},
+		{constraint: "=1.2", want: ">=1.2.0-0 <1.3.0-0"},
+		{constraint: "1.x", want: ">=1.0.0-0 <2.0.0-0"},
+		{constraint: "!=1.2.3", want: "<1.2.3||>1.2.3"},
+		{constraint: "!=1.x", want: "<1.0.0-0||>=2.0.0-0"},
+		{constraint: ">1.2.3", want: ">1.2.3"},
+		{constraint: ">1.2", want: ">=1.3.0-0"},
+		{constraint: ">=1.2.3-beta", want: ">=1.2.3-beta"},
+		{constraint: ">=1.2", want: ">=1.2.0-0"},
+		{constraint: "<1.2.3", want: "<1.2.3"},
+		{constraint: "<1.2", want: "<1.2.0"},
+		{constraint: "<=1.2.3", want: "<=1.2.3"},
+		{constraint: "<=1.2", want: "<1.3.0-0"},
+		{constraint: "~1.2.3", want: ">=1.2.3 <1.3.0"},
+		{constraint: "~1", want: ">=1.0.0-0 <2.0.0"},
+		{constraint: "~*", want: "*"},
+		{constraint: "^1.2.3", want: ">=1.2.3 <2.0.0"},
+		{constraint: "^0.2.3", want: ">=0.2.3 <0.3.0"},
+		{constraint: "^0.0.3", want: ">=0.0.3 <0.0.4"},
+		{constraint: "^0.0", want: ">=0.0.0-0 <0.1.0"},
+		{constraint: "^1.2.3-beta.2", want: ">=1.2.3-beta.2 <2.0.0"},
+		{constraint: "1.2.3-x", want: ">=1.2.3-0 <=1.2.3"},
+		{constraint: "1.x-beta", want: "<0.0.0-0"},
+		{constraint: "<0.0.0-0", want: "<0.0.0-0"},
+		{constraint: "<=0.0.0-0", want: "<=0.0.0-0"},
+		{constraint: ">=18446744073709551615.x", want: ">=18446744073709551615.0.0-0"},
+		{constraint: ">18446744073709551615.x", want: "<0.0.0-0"},
+		{constraint: "^18446744073709551615.1.2", want: ">=18446744073709551615.1.2"},
+
+		// AND
+		{constraint: ">=1.2.3, <2.0.0", want: ">=1.2.3 <2.0.0"},
+		{constraint: ">=1.2.3 <=1.2.3", want: "=1.2.3"},
+		{constraint: ">1.2.3 <1.2.3", want: "<0.0.0-0"},
+		{constraint: "^1.2.3, !=1.5.0", want: ">=1.2.3 <1.5.0||>1.5.0 <2.0.0"},
+		{constraint: "1.2.3 - 2.3", want: ">=1.2.3 <2.4.0-0"},
+
+		// OR
+		{constraint: "^1.2 || ~2.3.4, != 2.3.5", want: ">=1.2.0-0 <2.0.0||>=2.3.4 <2.3.5||>2.3.5 <2.4.0"},
+		{constraint: "<1.0.0 || >=1.0.0", want: "*"},
+		{constraint: "<=1.0.0 || >1.0.0", want: "*"},
+		{constraint: "<1.0.0 || >1.0.0", want: "<1.0.0||>1.0.0"},
+		{constraint: ">=2.0.0 || 1.x || <0.5.0", want: "<0.5.0||>=1.0.0-0 <2.0.0-0||>=2.0.0"},
+		{constraint: "^1.0.0 || ^1.5.0", want: ">=1.0.0 <2.0.0"},
+
+		// Zero padding
+		{constraint: "=1.2", opts: []semver.ConstraintOption{semver.WithZeroPadding(true)}, want: "=1.2.0"},
+		{constraint: ">1.2", opts: []semver.ConstraintOption{semver.WithZeroPadding(true)}, want: ">1.2.0"},
+		{constraint: "~1", opts: []semver.ConstraintOption{semver.WithZeroPadding(true)}, want: ">=1.0.0 <2.0.0"},
+		{constraint: "1.x", opts: []semver.ConstraintOption{semver.WithZeroPadding(true)}, want: ">=1.0.0-0 <2.0.0-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := semver.NewConstraints(tt.constraint, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.RangesIncludingPreReleases().String())
+
+			// Round trip
+			c, err = semver.NewConstraints(c.RangesIncludingPreReleases().String(), tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.RangesIncludingPreReleases().String())
+		})
+	}
+}
+
+func TestConstraints_RangesContains(t *testing.T) {
+	constraints := []string{
+		"*", "1.2.3", "=1.2", "1.x", "!=1.2.3", "!=1.x", ">1.2.3", ">1.2", ">=1.2.3-beta", ">=1.2",
+		"<1.2.3", "<1.2", "<=1.2.3", "<=1.2", "<1.2.3-beta.2", "~1.2.3", "~1.2", "~1", "~0.0.1-alpha",
+		"^1.2.3", "^1.2", "^0.2.3", "^0.0.3", "^0.0", "^0", "^1.2.3-beta.2", "1.2.3-x", "1.x-beta",
+		">=1.2.3, <2.0.0", "^1.2.3, !=1.5.0", "1.2.3 - 2.3", "1.2 - 2.3.4", "* - 2",
+		"^1.2 || ~2.3.4, != 2.3.5", "<1.0.0 || >1.0.0", ">=2.0.0 || 1.x || <0.5.0",
+	}
+	versions := []string{
+		"0.0.0-0", "0.0.0", "0.0.1-alpha", "0.0.1", "0.0.3", "0.0.4-0", "0.0.4", "0.1.0", "0.2.3", "0.2.9",
+		"0.3.0-alpha", "0.3.0", "0.9.9", "1.0.0-0", "1.0.0-alpha", "1.0.0", "1.1.9", "1.2.0-0", "1.2.0",
+		"1.2.3-alpha", "1.2.3-beta", "1.2.3-beta.2", "1.2.3-beta.10", "1.2.3", "1.2.4", "1.3.0-0",
+		"1.3.0", "1.5.0-rc.1", "1.5.0", "1.9.9", "2.0.0-0", "2.0.0-rc.1", "2.0.0", "2.3.4", "2.3.5",
+		"2.3.9", "2.4.0-0", "2.4.0", "3.0.0", "10.0.0",
+	}
+	for _, opts := range [][]semver.ConstraintOption{
+		{semver.WithPreRelease(true)},
+		{semver.WithPreRelease(true), semver.WithZeroPadding(true)},
+	} {
+		for _, constraint := range constraints {
+			c, err := semver.NewConstraints(constraint, opts...)
+			require.NoError(t, err)
+
+			ranges := c.RangesIncludingPreReleases()
+			for _, s := range versions {
+				v := semver.MustParse(s)
+				assert.Equal(t, c.Check(v), ranges.Contains(v), "%s vs %s (%s)", constraint, s, ranges)
+			}
+		}
+	}
+}
+
+func TestRange_Bounds(t *testing.T) {
+	c, err := semver.NewConstraints("<1.2.3 || ^2.0.0")
+	require.NoError(t, err)
+
+	ranges := c.RangesIncludingPreReleases()
+	require.Len(t, ranges, 2)
+
+	lower, upper := ranges[0].Lower(), ranges[0].Upper()
+	assert.True(t, lower.IsInfinite())
+	assert.Equal(t, "-∞", lower.String())
+	assert.False(t, upper.IsInfinite())
+	assert.False(t, upper.Inclusive())
+	assert.Equal(t, "1.2.3", upper.Version().String())
+
+	lower, upper = ranges[1].Lower(), ranges[1].Upper()
+	assert.True(t, lower.Inclusive())
+	assert.Equal(t, "2.0.0", lower.String())
+	assert.Equal(t, "3.0.0", upper.String())
+
+	c, err = semver.NewConstraints(">=1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "∞", c.RangesIncludingPreReleases()[0].Upper().String())
+}
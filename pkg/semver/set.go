@@ -0,0 +1,298 @@
+package semver
+
+import (
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// Intersect returns constraints satisfied by versions which satisfy both cs and o.
+// The result has the options of cs, and o is interpreted with them.
+//
+// With WithPreRelease(true), the result is built from RangesIncludingPreReleases(),
+// e.g. ">=1.0.0 <2.0.0" ∩ ">=1.5.0 || <0.5.0" => ">=1.5.0,<2.0.0".
+// Otherwise, the AND groups are combined as they are, since whether a pre-release satisfies
+// an AND group depends on its comparators, e.g. ">=1.0.0 <2.0.0" ∩ ">=1.5.0 || <0.5.0" => ">=1.0.0,<2.0.0,>=1.5.0".
+// AND groups which no version satisfies are removed.
+func (cs Constraints) Intersect(o Constraints) Constraints {
+	if cs.conf.includePreRelease {
+		return newRangeConstraints(intersectRanges(cs.RangesIncludingPreReleases(), o.RangesIncludingPreReleases()), cs.conf)
+	}
+
+	var css [][]constraint
+	for _, ands1 := range cs.constraints {
+		for _, ands2 := range o.constraints {
+			ands1, ands2 := flatten(ands1), flatten(ands2)
+			if cs.conf.npmPreRelease {
+				// A pre-release must be allowed by both AND groups
+				ands1, ands2 = npmReleaseConstraints(ands1, ands2), npmReleaseConstraints(ands2, ands1)
+			}
+			css = append(css, append(ands1, ands2...))
+		}
+	}
+	return cs.withConstraints(css)
+}
+
+// Union returns constraints satisfied by versions which satisfy cs or o.
+// The result has the options of cs, and o is interpreted with them.
+//
+// With WithPreRelease(true), the result is built from RangesIncludingPreReleases(), e.g. "^1.2.3 || ^2.0.0" => ">=1.2.3,<3.0.0".
+// Otherwise, the AND groups of both are joined with "||".
+// AND groups which no version satisfies are removed.
+func (cs Constraints) Union(o Constraints) Constraints {
+	if cs.conf.includePreRelease {
+		return newRangeConstraints(append(cs.RangesIncludingPreReleases(), o.RangesIncludingPreReleases()...), cs.conf)
+	}
+
+	css := append(append([][]constraint{}, cs.constraints...), o.constraints...)
+	return cs.withConstraints(css)
+}
+
+// Complement returns constraints satisfied by versions which don't satisfy cs.
+// The result is built from RangesIncludingPreReleases() and has the options of cs, e.g. "^1.2.3" => "<1.2.3||>=2.0.0".
+//
+// Without WithPreRelease(true), a pre-release satisfies the result only if a comparator in the AND group
+// has a pre-release, as with the other constraints. So, neither cs nor the result may be satisfied by a pre-release,
+// e.g. 2.1.0-alpha satisfies neither "^1.2.3" nor "<1.2.3||>=2.0.0".
+func (cs Constraints) Complement() Constraints {
+	return newRangeConstraints(complementRanges(cs.RangesIncludingPreReleases()), cs.conf)
+}
+
+// withConstraints returns constraints with the given AND groups and the options of cs.
+// AND groups which no version satisfies are removed.
+func (cs Constraints) withConstraints(css [][]constraint) Constraints {
+	var satisfiable [][]constraint
+	for _, ands := range css {
+		if len(andRanges(ands)) > 0 {
+			satisfiable = append(satisfiable, ands)
+		}
+	}
+	if len(satisfiable) == 0 {
+		return newRangeConstraints(nil, cs.conf)
+	}
+
+	return Constraints{
+		constraints: satisfiable,
+		conf:        cs.conf,
+	}
+}
+
+// flatten replaces hyphen ranges with their comparators, so that they can be combined with the other comparators.
+func flatten(constraints []constraint) []constraint {
+	var flattened []constraint
+	for _, c := range constraints {
+		if c.expansion != nil {
+			flattened = append(flattened, c.expansion...)
+			continue
+		}
+		flattened = append(flattened, c)
+	}
+	return flattened
+}
+
+// npmReleaseConstraints replaces the comparators with a pre-release whose major.minor.patch tuple
+// is not in the other AND group with the equivalent ones without a pre-release,
+// so that such pre-releases are not allowed in the combined AND group.
+func npmReleaseConstraints(constraints, others []constraint) []constraint {
+	var replaced []constraint
+	for _, c := range constraints {
+		if c.version.preRelease.IsNull() || c.version.IsAny() || hasPreReleaseTuple(others, c.version) {
+			replaced = append(replaced, c)
+			continue
+		}
+		if rc, ok := releaseConstraint(c); ok {
+			replaced = append(replaced, rc)
+		}
+	}
+	return replaced
+}
+
+func hasPreReleaseTuple(constraints []constraint, v Version) bool {
+	for _, c := range constraints {
+		if !c.version.preRelease.IsNull() && sameTuple(v, c.version) {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseConstraint returns a constraint without a pre-release which is satisfied by the same versions
+// except for the pre-releases of the same major.minor.patch, e.g. ">1.2.3-beta" => ">=1.2.3".
+// It returns false if the constraint is satisfied by any other version, i.e. "!=1.2.3-beta".
+func releaseConstraint(c constraint) (constraint, bool) {
+	v := c.version
+	v.preRelease = nil
+
+	var op Operator
+	switch c.op {
+	case OpEqual:
+		// No release is equal to the pre-release
+		return newComparator(OpLessThan, zero), true
+	case OpNotEqual:
+		return constraint{}, false
+	case OpGreaterThan, OpGreaterThanOrEqual:
+		op = OpGreaterThanOrEqual
+	case OpLessThan, OpLessThanOrEqual:
+		op = OpLessThan
+	default:
+		op = c.op
+	}
+	return newComparator(op, v), true
+}
+
+// newComparator returns a constraint such as ">=1.2.3".
+func newComparator(op Operator, v Version) constraint {
+	v.original = v.String()
+	return constraint{
+		version:  v,
+		op:       op,
+		operator: constraintOperators[op.String()],
+		original: op.String() + v.String(),
+	}
+}
+
+// newRangeConstraints returns constraints satisfied by the versions in the ranges.
+// Each range is an AND group, e.g. ">=1.2.3 <2.0.0".
+func newRangeConstraints(rs Ranges, conf conf) Constraints {
+	rs = normalizeRanges(rs)
+	if len(rs) == 0 {
+		// No version is less than 0.0.0-0
+		return Constraints{
+			constraints: [][]constraint{{newComparator(OpLessThan, zero)}},
+			conf:        conf,
+		}
+	}
+
+	var css [][]constraint
+	for _, r := range rs {
+		css = append(css, r.constraints())
+	}
+	return Constraints{
+		constraints: css,
+		conf:        conf,
+	}
+}
+
+// constraints returns the comparators of the range.
+func (r Range) constraints() []constraint {
+	switch {
+	case r.lower.IsInfinite() && r.upper.IsInfinite():
+		return []constraint{{
+			version: Version{
+				major:      part.Any(true),
+				minor:      part.Any(true),
+				patch:      part.Any(true),
+				preRelease: part.NewParts("*"),
+			},
+			op:       OpEqual,
+			operator: constraintEqual,
+			original: "*",
+		}}
+	case r.lower.version.Compare(r.upper.version) == 0:
+		return []constraint{newComparator(OpEqual, r.lower.version)}
+	}
+
+	var cs []constraint
+	if !r.lower.IsInfinite() {
+		op := OpGreaterThan
+		if r.lower.inclusive {
+			op = OpGreaterThanOrEqual
+		}
+		cs = append(cs, newComparator(op, r.lower.version))
+	}
+	if !r.upper.IsInfinite() {
+		op := OpLessThan
+		if r.upper.inclusive {
+			op = OpLessThanOrEqual
+		}
+		cs = append(cs, newComparator(op, r.upper.version))
+	}
+	return cs
+}
+
+// IsSatisfiable returns true if any version satisfies the constraints,
+// e.g. false for ">2.0.0, <1.0.0" and ">=1.2.3-alpha <=1.2.3-beta" without WithPreRelease(true).
+func (cs Constraints) IsSatisfiable() bool {
+	releases, preReleases := cs.versionRanges()
+	return hasRelease(releases) || hasPreRelease(preReleases)
+}
+
+// Overlaps returns true if any version satisfies both cs and o.
+// Unlike Intersect, each of them is interpreted with its own options.
+func (cs Constraints) Overlaps(o Constraints) bool {
+	releases1, preReleases1 := cs.versionRanges()
+	releases2, preReleases2 := o.versionRanges()
+	return hasRelease(intersectRanges(releases1, releases2)) ||
+		hasPreRelease(intersectRanges(preReleases1, preReleases2))
+}
+
+// IsSubsetOf returns true if all the versions which satisfy cs also satisfy o,
+// e.g. "~1.2.3" is a subset of "^1.0.0". Each of them is interpreted with its own options.
+func (cs Constraints) IsSubsetOf(o Constraints) bool {
+	releases1, preReleases1 := cs.versionRanges()
+	releases2, preReleases2 := o.versionRanges()
+	return !hasRelease(intersectRanges(releases1, complementRanges(releases2))) &&
+		!hasPreRelease(intersectRanges(preReleases1, complementRanges(preReleases2)))
+}
+
+// versionRanges returns the ranges of the versions which satisfy the constraints.
+// A release version satisfies them if it's in releases, and a pre-release version if it's in preReleases.
+func (cs Constraints) versionRanges() (releases, preReleases Ranges) {
+	releases = cs.RangesIncludingPreReleases()
+	if cs.conf.includePreRelease {
+		return releases, releases
+	}
+
+	for _, ands := range cs.constraints {
+		ands = flatten(ands)
+		switch {
+		case cs.conf.npmPreRelease:
+			// Only pre-releases with the same major.minor.patch tuple as a comparator, see npmPreCheck
+			preReleases = append(preReleases, intersectRanges(andRanges(ands), preReleaseTupleRanges(ands))...)
+		case allPreRelease(ands):
+			// Pre-releases are allowed only if all the comparators have a pre-release, see preCheck
+			preReleases = append(preReleases, andRanges(ands)...)
+		}
+	}
+	return releases, normalizeRanges(preReleases)
+}
+
+func allPreRelease(constraints []constraint) bool {
+	for _, c := range constraints {
+		if c.version.preRelease.IsNull() {
+			return false
+		}
+	}
+	return true
+}
+
+// preReleaseTupleRanges returns the pre-releases with the same major.minor.patch tuple as the comparators
+// with a pre-release, e.g. ">=1.2.3-beta" => ">=1.2.3-0 <1.2.3".
+func preReleaseTupleRanges(constraints []constraint) Ranges {
+	var rs Ranges
+	for _, c := range constraints {
+		if c.version.preRelease.IsNull() || c.version.major.IsAny() || c.version.minor.IsAny() || c.version.patch.IsAny() {
+			continue
+		}
+		upper := Version{
+			major:      numeric(c.version.major),
+			minor:      numeric(c.version.minor),
+			patch:      numeric(c.version.patch),
+			preRelease: part.Parts{},
+		}
+		upper.original = upper.String()
+		lower := upper
+		lower.preRelease = part.NewParts("0")
+		lower.original = lower.String()
+		rs = append(rs, Range{lower: Bound{version: lower, inclusive: true}, upper: Bound{version: upper}})
+	}
+	return normalizeRanges(rs)
+}
+
+// hasRelease tests if any of the ranges contains a release version.
+func hasRelease(rs Ranges) bool {
+	return len(releaseRanges(rs)) > 0
+}
+
+// hasPreRelease tests if any of the ranges contains a pre-release version.
+func hasPreRelease(rs Ranges) bool {
+	return len(preReleaseRanges(rs)) > 0
+}
@@ -0,0 +1,275 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestConstraints_Intersect(t *testing.T) {
+	tests := []struct {
+		name string
+		c1   string
+		c2   string
+		opts []semver.ConstraintOption
+		want string
+	}{
+		{name: "AND", c1: ">=1.0.0 <2.0.0", c2: ">=1.5.0", want: ">=1.0.0,<2.0.0,>=1.5.0"},
+		{name: "OR", c1: ">=1.0.0 <2.0.0", c2: ">=1.5.0 || <0.5.0", want: ">=1.0.0,<2.0.0,>=1.5.0"},
+		{name: "hyphen range", c1: "1.2.3 - 2.3.4", c2: "^2.0.0", want: ">=1.2.3,<=2.3.4,^2.0.0"},
+		{name: "disjoint", c1: "^1.0.0", c2: "^2.0.0", want: "<0.0.0-0"},
+		{
+			name: "npm",
+			c1:   ">=1.0.0-beta <2.0.0",
+			c2:   ">=0.5.0 || >=1.0.0-alpha",
+			opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)},
+			want: ">=1.0.0,<2.0.0,>=0.5.0||>=1.0.0-beta,<2.0.0,>=1.0.0-alpha",
+		},
+		{
+			name: "pre-release",
+			c1:   ">=1.0.0 <2.0.0",
+			c2:   ">=1.5.0 || <0.5.0",
+			opts: []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want: ">=1.5.0,<2.0.0",
+		},
+		{
+			name: "pre-release disjoint",
+			c1:   "^1.0.0",
+			c2:   ">=2.0.0",
+			opts: []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want: "<0.0.0-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c1, err := semver.NewConstraints(tt.c1, tt.opts...)
+			require.NoError(t, err)
+			c2, err := semver.NewConstraints(tt.c2, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.Intersect(c2).String())
+		})
+	}
+}
+
+func TestConstraints_Union(t *testing.T) {
+	tests := []struct {
+		name string
+		c1   string
+		c2   string
+		opts []semver.ConstraintOption
+		want string
+	}{
+		{name: "OR", c1: "^1.2.3", c2: "^2.0.0", want: "^1.2.3||^2.0.0"},
+		{name: "unsatisfiable", c1: ">2.0.0 <1.0.0", c2: "~1.2", want: "~1.2"},
+		{
+			name: "pre-release",
+			c1:   "^1.2.3",
+			c2:   "^2.0.0",
+			opts: []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want: ">=1.2.3,<3.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c1, err := semver.NewConstraints(tt.c1, tt.opts...)
+			require.NoError(t, err)
+			c2, err := semver.NewConstraints(tt.c2, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.Union(c2).String())
+		})
+	}
+}
+
+func TestConstraints_Complement(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       []semver.ConstraintOption
+		want       string
+	}{
+		{constraint: "^1.2.3", want: "<1.2.3||>=2.0.0"},
+		{constraint: "!=1.2.3", want: "=1.2.3"},
+		{constraint: "1.x", want: "<1.0.0-0||>=2.0.0-0"},
+		{constraint: "*", want: "<0.0.0-0"},
+		{constraint: "<0.0.0-0", want: "*"},
+		{constraint: "^1.2.3", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: "<1.2.3||>=2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := semver.NewConstraints(tt.constraint, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Complement().String())
+		})
+	}
+}
+
+func TestConstraints_SetCheck(t *testing.T) {
+	constraints := []string{
+		"*", "1.2.3", "1.x", "!=1.2.3", ">1.2.3", ">=1.2.3-beta", "<1.2.3", "<=1.2", "<1.2.3-beta.2",
+		"~1.2.3", "~1", "^1.2.3", "^0.0.3", "^1.2.3-beta.2", "1.2.3-x", "=1.2.3-beta", "!=1.2.3-beta",
+		">=1.2.3, <2.0.0", "^1.2.3, !=1.5.0", "1.2.3 - 2.3", "1.0.0-alpha - 1.2.3-beta",
+		"^1.2 || ~2.3.4, != 2.3.5", ">=2.0.0 || 1.x || <0.5.0", ">=1.0.0-0 <=1.5.0-rc.1 || >2.0.0",
+	}
+	versions := []string{
+		"0.0.0-0", "0.0.0", "0.0.3", "0.0.4-0", "0.1.0", "0.9.9", "1.0.0-0", "1.0.0-alpha", "1.0.0",
+		"1.2.0-0", "1.2.0", "1.2.3-alpha", "1.2.3-beta", "1.2.3-beta.2", "1.2.3-beta.10", "1.2.3",
+		"1.2.4", "1.3.0-0", "1.3.0", "1.5.0-rc.1", "1.5.0-rc.2", "1.5.0", "1.9.9", "2.0.0-0", "2.0.0-rc.1",
+		"2.0.0", "2.3.4", "2.3.5", "2.4.0-0", "2.4.0", "3.0.0", "10.0.0",
+	}
+	for _, tt := range []struct {
+		opts       []semver.ConstraintOption
+		preRelease bool
+	}{
+		{},
+		{opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, preRelease: true},
+		{opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}},
+		{opts: []semver.ConstraintOption{semver.WithZeroPadding(true)}},
+	} {
+		for _, s1 := range constraints {
+			c1, err := semver.NewConstraints(s1, tt.opts...)
+			require.NoError(t, err)
+
+			complement := c1.Complement()
+			assert.True(t, c1.IsSubsetOf(c1), s1)
+			for _, s := range versions {
+				if c1.Check(semver.MustParse(s)) {
+					assert.True(t, c1.IsSatisfiable(), "%s is satisfied by %s", s1, s)
+				}
+			}
+			for _, s2 := range constraints {
+				c2, err := semver.NewConstraints(s2, tt.opts...)
+				require.NoError(t, err)
+
+				intersection, union := c1.Intersect(c2), c1.Union(c2)
+				overlaps, subset := c1.Overlaps(c2), c1.IsSubsetOf(c2)
+				for _, s := range versions {
+					v := semver.MustParse(s)
+					assert.Equal(t, c1.Check(v) && c2.Check(v), intersection.Check(v),
+						"%s ∩ %s vs %s (%s)", s1, s2, s, intersection)
+					assert.Equal(t, c1.Check(v) || c2.Check(v), union.Check(v),
+						"%s ∪ %s vs %s (%s)", s1, s2, s, union)
+					if c1.Check(v) && c2.Check(v) {
+						assert.True(t, overlaps, "%s overlaps %s with %s", s1, s2, s)
+					}
+					if c1.Check(v) && !c2.Check(v) {
+						assert.False(t, subset, "%s ⊆ %s except %s", s1, s2, s)
+					}
+				}
+				assert.Equal(t, intersection.IsSatisfiable(), overlaps, "%s overlaps %s", s1, s2)
+
+				// The result can be parsed with the same options
+				parsed, err := semver.NewConstraints(intersection.String(), tt.opts...)
+				require.NoError(t, err, intersection.String())
+				for _, s := range versions {
+					v := semver.MustParse(s)
+					assert.Equal(t, intersection.Check(v), parsed.Check(v), "%s vs %s", intersection, s)
+				}
+			}
+
+			for _, s := range versions {
+				v := semver.MustParse(s)
+				if v.IsPreRelease() && !tt.preRelease {
+					// Pre-releases satisfy the complement only if a comparator has a pre-release
+					if complement.Check(v) {
+						assert.False(t, c1.Check(v), "¬%s vs %s (%s)", s1, s, complement)
+					}
+					continue
+				}
+				assert.Equal(t, !c1.Check(v), complement.Check(v), "¬%s vs %s (%s)", s1, s, complement)
+			}
+		}
+	}
+}
+
+func TestConstraints_IsSatisfiable(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       []semver.ConstraintOption
+		want       bool
+	}{
+		{constraint: "*", want: true},
+		{constraint: "^1.2.3, !=1.5.0", want: true},
+		{constraint: ">2.0.0, <1.0.0", want: false},
+		{constraint: ">1.2.3 <1.2.4", want: false},
+		{constraint: ">1.2.3 <=1.2.4", want: true},
+		{constraint: "<0.0.0-0", want: false},
+		{constraint: "1.x-beta", want: false},
+		{constraint: ">2.0.0, <1.0.0 || =1.2.3", want: true},
+		{constraint: ">=1.2.3-alpha <=1.2.3-beta", want: true},
+		{constraint: ">=1.2.3-alpha, <=1.2.3-beta, !=1.0.0", want: false},
+		{constraint: ">=1.2.3-alpha, <=1.2.3-beta, !=1.0.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: true},
+		{constraint: ">1.2.3-alpha <1.2.3-alpha.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: false},
+		{constraint: ">1.2.3-alpha <=1.2.3-alpha.0", want: true},
+		{constraint: ">=1.2.3-alpha <1.2.4-beta", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: true},
+		{constraint: ">1.2.3 <1.2.4-beta", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: true},
+		{constraint: ">1.2.3-beta <1.2.4-alpha, !=1.2.3", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: true},
+		{constraint: ">1.2.3-beta, <1.2.4-alpha, >1.2.3", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: true},
+		{constraint: ">1.2.3-beta, <=1.2.3-rc, !=1.2.3-beta.0", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: true},
+		{constraint: ">1.2.3, <1.2.4-alpha, !=1.2.4-0", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: true},
+		{constraint: ">1.2.3, <=1.2.4-0, !=1.2.4-0", opts: []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := semver.NewConstraints(tt.constraint, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.IsSatisfiable())
+		})
+	}
+}
+
+func TestConstraints_Overlaps(t *testing.T) {
+	tests := []struct {
+		c1   string
+		c2   string
+		opts []semver.ConstraintOption
+		want bool
+	}{
+		{c1: "^1.2.3", c2: ">=1.9.0 <3.0.0", want: true},
+		{c1: "^1.2.3", c2: "^2.0.0", want: false},
+		{c1: "<=1.2.3", c2: ">=1.2.3", want: true},
+		{c1: "<1.2.3", c2: ">=1.2.3-0", want: false},
+		{c1: "<1.2.3", c2: ">=1.2.3-0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: true},
+		{c1: "<1.2.3-beta", c2: ">=1.2.3-alpha", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c1+" ∩ "+tt.c2, func(t *testing.T) {
+			c1, err := semver.NewConstraints(tt.c1, tt.opts...)
+			require.NoError(t, err)
+			c2, err := semver.NewConstraints(tt.c2, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.Overlaps(c2))
+			assert.Equal(t, tt.want, c2.Overlaps(c1))
+		})
+	}
+}
+
+func TestConstraints_IsSubsetOf(t *testing.T) {
+	tests := []struct {
+		c1   string
+		c2   string
+		opts []semver.ConstraintOption
+		want bool
+	}{
+		{c1: "~1.2.3", c2: "^1.0.0", want: true},
+		{c1: "^1.0.0", c2: "~1.2.3", want: false},
+		{c1: "1.2.3 - 1.4.0", c2: ">=1.0.0 <1.4.0 || 1.4.x", want: true},
+		{c1: ">2.0.0, <1.0.0", c2: "<0.0.0-0", want: true},
+		{c1: "^1.0.0-0", c2: "^1.0.0", want: false},
+		{c1: "^1.0.0", c2: ">=1.0.0-0 <2.0.0-0", want: true},
+		{c1: ">=1.0.0 <2.0.0-0", c2: "^1.0.0", want: true},
+		{c1: ">=1.0.0 <2.0.0-0", c2: "^1.0.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: true},
+		{c1: ">=1.0.0 <=2.0.0-0", c2: "^1.0.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: true},
+		{c1: ">=1.0.0 <=2.0.0", c2: "^1.0.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c1+" ⊆ "+tt.c2, func(t *testing.T) {
+			c1, err := semver.NewConstraints(tt.c1, tt.opts...)
+			require.NoError(t, err)
+			c2, err := semver.NewConstraints(tt.c2, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.IsSubsetOf(c2))
+		})
+	}
+}
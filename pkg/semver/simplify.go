@@ -0,0 +1,273 @@
+package semver
+
+import (
+	"slices"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// Simplify returns equivalent constraints with redundant comparators and overlapping OR branches merged,
+// e.g. ">=1.0.0, >=1.2.0, <3.0.0 || ^1.5.0" => ">=1.2.0,<3.0.0".
+//
+// With WithPreRelease(true), the result is built from RangesIncludingPreReleases().
+// Otherwise, the AND groups which no pre-release satisfies are merged into ranges of release versions,
+// e.g. ">1.2.3, <=1.5.0" => ">=1.2.4,<1.5.1", and the other AND groups are kept as they are
+// since whether a pre-release satisfies them depends on their comparators.
+// AND groups which no version satisfies are removed.
+func (cs Constraints) Simplify() Constraints {
+	if cs.conf.includePreRelease {
+		return cs.withRanges(canonicalRanges(cs.RangesIncludingPreReleases()))
+	}
+
+	var releases Ranges
+	var css [][]constraint
+	for _, ands := range cs.constraints {
+		rs, preRs := Constraints{constraints: [][]constraint{ands}, conf: cs.conf}.versionRanges()
+		if hasPreRelease(preRs) {
+			css = append(css, ands)
+			continue
+		}
+		releases = append(releases, rs...)
+	}
+
+	rs := releaseRanges(releases)
+	if len(rs) == 0 && len(css) > 0 {
+		return Constraints{
+			constraints: css,
+			conf:        cs.conf,
+		}
+	}
+
+	simplified := cs.withRanges(rs)
+	simplified.constraints = append(simplified.constraints, css...)
+	return simplified
+}
+
+// Canonical returns a string which is identical for constraints satisfied by the same versions,
+// e.g. ">=1.2, <2 || ^1.5.0" and "^1.2.0-0, <2.0.0" are both ">=1.2.0-0,<2.0.0" with WithPreRelease(true).
+//
+// With WithPreRelease(true), or if no pre-release satisfies the constraints, it is the same as Simplify().String().
+// Otherwise, the ranges of release versions are followed by the ranges of pre-release versions
+// which satisfy the constraints. Note that the ranges of pre-release versions may be satisfied by
+// more release versions when they are parsed again, e.g. "^1.2.3-beta" => ">=1.2.3,<2.0.0||>=1.2.3-beta,<2.0.1-0".
+func (cs Constraints) Canonical() string {
+	if cs.conf.includePreRelease {
+		return cs.withRanges(canonicalRanges(cs.RangesIncludingPreReleases())).String()
+	}
+
+	releases, preReleases := cs.versionRanges()
+	releases, preReleases = releaseRanges(releases), preReleaseRanges(preReleases)
+	return cs.withRanges(append(releases, preReleases...)).String()
+}
+
+// withRanges returns constraints with an AND group for each range and the options of cs.
+// Unlike newRangeConstraints, the ranges are not normalized.
+func (cs Constraints) withRanges(rs Ranges) Constraints {
+	if len(rs) == 0 {
+		return newRangeConstraints(nil, cs.conf)
+	}
+
+	css := make([][]constraint, len(rs))
+	for i, r := range rs {
+		css[i] = r.constraints()
+	}
+	return Constraints{
+		constraints: css,
+		conf:        cs.conf,
+	}
+}
+
+// canonicalRanges returns the ranges in the canonical form, where the bounds have no build metadata,
+// and a bound is exclusive only if it can't be inclusive, e.g. ">=1.2.4-0" => ">1.2.3", "<1.2.4-0" => "<=1.2.3".
+func canonicalRanges(rs Ranges) Ranges {
+	var canonical Ranges
+	for _, r := range normalizeRanges(rs) {
+		lower, upper := r.lower, r.upper
+		if !lower.IsInfinite() {
+			// Inclusive
+			lower.version = canonicalVersion(lower.version)
+			if !lower.inclusive {
+				lower = Bound{version: successor(lower.version), inclusive: true}
+			}
+		}
+		if !upper.IsInfinite() {
+			// Exclusive
+			upper.version = canonicalVersion(upper.version)
+			if upper.inclusive {
+				upper = Bound{version: successor(upper.version)}
+			}
+		}
+		if r, ok := newRange(lower, upper); ok {
+			canonical = append(canonical, r)
+		}
+	}
+
+	canonical = normalizeRanges(canonical)
+	for i, r := range canonical {
+		switch {
+		case r.lower.IsInfinite():
+		case r.lower.version.Compare(zero) == 0:
+			canonical[i].lower = Bound{version: negativeInfinity}
+		case !r.upper.IsInfinite() && r.upper.version.Compare(successor(r.lower.version)) == 0:
+			// A single version
+			canonical[i].upper = r.lower
+			continue
+		}
+		if v, ok := predecessor(r.lower.version); ok {
+			canonical[i].lower = Bound{version: v}
+		}
+		if v, ok := predecessor(r.upper.version); ok {
+			canonical[i].upper = Bound{version: v, inclusive: true}
+		}
+	}
+	return canonical
+}
+
+// releaseRanges returns the ranges of the release versions in the ranges,
+// where a lower bound is inclusive and an upper bound is exclusive, e.g. ">1.2.3 <=1.5.0-beta" => ">=1.2.4 <1.5.0".
+// A range which contains a single release version is shown as "=1.2.3".
+func releaseRanges(rs Ranges) Ranges {
+	var releases Ranges
+	for _, r := range normalizeRanges(rs) {
+		// The minimum release version not less than the lower bound
+		lower := Bound{version: Version{major: part.Zero, minor: part.Zero, patch: part.Zero, preRelease: part.Parts{}}, inclusive: true}
+		switch {
+		case r.lower.IsInfinite():
+		case !r.lower.version.preRelease.IsNull(), r.lower.inclusive:
+			// e.g. >1.2.3-beta => >=1.2.3
+			lower.version = release(r.lower.version)
+		default:
+			// e.g. >1.2.3 => >=1.2.4
+			lower.version = r.lower.version.bump(LevelPatch)
+		}
+
+		upper := r.upper
+		switch {
+		case upper.IsInfinite():
+		case !upper.version.preRelease.IsNull():
+			// e.g. <=1.2.3-beta => <1.2.3
+			upper = Bound{version: release(upper.version)}
+		case upper.inclusive:
+			// e.g. <=1.2.3 => <1.2.4
+			upper = Bound{version: upper.version.bump(LevelPatch)}
+		default:
+			upper.version = release(upper.version)
+		}
+
+		if lower.version.major != part.Infinity && lower.version.Compare(upper.version) < 0 {
+			releases = append(releases, Range{lower: lower, upper: upper})
+		}
+	}
+
+	releases = normalizeRanges(releases)
+	for i, r := range releases {
+		switch {
+		case r.lower.version.Compare(release(zero)) == 0:
+			releases[i].lower = Bound{version: negativeInfinity}
+		case !r.upper.IsInfinite() && r.upper.version.Compare(r.lower.version.bump(LevelPatch)) == 0:
+			// A single version
+			releases[i].upper = r.lower
+		}
+	}
+	return releases
+}
+
+// preReleaseRanges returns the ranges of the pre-release versions in the ranges,
+// where a bound is a pre-release version, and a lower bound is inclusive and an upper bound is exclusive,
+// e.g. ">1.2.3 <=1.5.0-beta" => ">=1.2.4-0 <1.5.0-beta.0". A range which contains a single pre-release version is shown as "=1.2.3-beta".
+func preReleaseRanges(rs Ranges) Ranges {
+	var preReleases Ranges
+	for _, r := range normalizeRanges(rs) {
+		// The minimum pre-release version not less than the lower bound
+		lower := Bound{version: zero, inclusive: true}
+		switch {
+		case r.lower.IsInfinite():
+		case r.lower.version.preRelease.IsNull(), !r.lower.inclusive:
+			// e.g. >=1.2.3 => >=1.2.4-0, >1.2.3-beta => >=1.2.3-beta.0
+			lower.version = successor(canonicalVersion(r.lower.version))
+		default:
+			lower.version = canonicalVersion(r.lower.version)
+		}
+
+		upper := r.upper
+		switch {
+		case upper.IsInfinite():
+		case upper.version.preRelease.IsNull(), upper.inclusive:
+			// e.g. <1.2.3 => <1.2.4-0, <=1.2.3-beta => <1.2.3-beta.0
+			upper = Bound{version: successor(canonicalVersion(upper.version))}
+		default:
+			upper.version = canonicalVersion(upper.version)
+		}
+
+		if r, ok := newRange(lower, upper); ok && lower.version.major != part.Infinity {
+			preReleases = append(preReleases, r)
+		}
+	}
+
+	preReleases = normalizeRanges(preReleases)
+	for i, r := range preReleases {
+		if !r.upper.IsInfinite() && r.upper.version.Compare(successor(r.lower.version)) == 0 {
+			// A single version
+			preReleases[i].upper = r.lower
+		}
+	}
+	return preReleases
+}
+
+// canonicalVersion returns the version without build metadata and missing parts.
+func canonicalVersion(v Version) Version {
+	c := Version{
+		major:      numeric(v.major),
+		minor:      numeric(v.minor),
+		patch:      numeric(v.patch),
+		preRelease: v.preRelease,
+	}
+	if c.preRelease.IsNull() {
+		c.preRelease = part.Parts{}
+	}
+	c.original = c.String()
+	return c
+}
+
+// release returns the release version of the same major.minor.patch.
+func release(v Version) Version {
+	v = canonicalVersion(v)
+	v.preRelease = part.Parts{}
+	v.original = v.String()
+	return v
+}
+
+// successor returns the minimum version greater than v, e.g. 1.2.3 => 1.2.4-0, 1.2.3-beta => 1.2.3-beta.0
+func successor(v Version) Version {
+	if v.preRelease.IsNull() {
+		v = v.bump(LevelPatch)
+		if v.major == part.Infinity {
+			return v
+		}
+		v.preRelease = part.NewParts("0")
+	} else {
+		v.preRelease = append(slices.Clone(v.preRelease), part.Zero)
+	}
+	v.original = v.String()
+	return v
+}
+
+// predecessor returns the maximum version less than v if v is returned by successor,
+// e.g. 1.2.4-0 => 1.2.3, 1.2.3-beta.0 => 1.2.3-beta
+func predecessor(v Version) (Version, bool) {
+	n := len(v.preRelease)
+	switch {
+	case n == 0 || v.preRelease[n-1] != part.Zero:
+		return Version{}, false
+	case n > 1:
+		v.preRelease = slices.Clone(v.preRelease[:n-1])
+	case v.patch == part.Zero:
+		// e.g. 1.3.0-0 is not the successor of any version with the same major.minor
+		return Version{}, false
+	default:
+		v.patch = v.patch.(part.Uint64) - 1
+		v.preRelease = part.Parts{}
+	}
+	v.original = v.String()
+	return v, true
+}
@@ -0,0 +1,163 @@
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestConstraints_Simplify(t *testing.T) {
+	tests := []struct {
+		constraint string
+		opts       []semver.ConstraintOption
+		want       string
+	}{
+		{constraint: ">=1.0.0, >=1.2.0, <3.0.0 || ^1.5.0", want: ">=1.2.0,<3.0.0"},
+		{constraint: ">1.2.3, <=1.5.0", want: ">=1.2.4,<1.5.1"},
+		{constraint: "^1.2.3 || ^2.0.0 || 3.x", want: ">=1.2.3,<4.0.0"},
+		{constraint: "^1.2.3, !=1.5.0", want: ">=1.2.3,<1.5.0||>=1.5.1,<2.0.0"},
+		{constraint: ">=1.2.3, <=1.2.3", want: "=1.2.3"},
+		{constraint: ">=1.2.3-beta, <1.2.4", want: "=1.2.3"},
+		{constraint: ">=1.2.3-beta, <1.2.4-0", want: ">=1.2.3-beta,<1.2.4-0"},
+		{constraint: ">=1.2.3-alpha, <1.2.3-beta", want: ">=1.2.3-alpha,<1.2.3-beta"},
+		{constraint: ">2.0.0, <1.0.0", want: "<0.0.0-0"},
+		{constraint: ">2.0.0, <1.0.0 || ^1.2.3-beta", want: "^1.2.3-beta"},
+		{constraint: "<1.0.0 || >=1.0.0", want: "*"},
+		{constraint: "*", want: "*"},
+		{constraint: "1.2.3 - 2.3", want: ">=1.2.3,<2.4.0"},
+		{constraint: "<=0.0.0", want: "<0.0.1"},
+
+		// Pre-release
+		{constraint: ">=1.0.0, >=1.2.0, <3.0.0 || ^1.5.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: ">=1.2.0,<3.0.0"},
+		{constraint: ">1.2.3, <=1.5.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: ">1.2.3,<=1.5.0"},
+		{constraint: ">=1.2.4-0 <1.3.0-0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: ">1.2.3,<1.3.0-0"},
+		{constraint: ">=1.2.3-beta <1.2.3-beta.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: "=1.2.3-beta"},
+		{constraint: "1.2.x || 1.3.x", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: ">=1.2.0-0,<1.4.0-0"},
+		{constraint: ">=0.0.0-0 <1.0.0", opts: []semver.ConstraintOption{semver.WithPreRelease(true)}, want: "<1.0.0"},
+
+		// Zero padding
+		{constraint: ">=1.2, <=1.5", opts: []semver.ConstraintOption{semver.WithZeroPadding(true)}, want: ">=1.2.0,<1.5.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := semver.NewConstraints(tt.constraint, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Simplify().String())
+		})
+	}
+}
+
+func TestConstraints_Canonical(t *testing.T) {
+	tests := []struct {
+		constraints []string
+		opts        []semver.ConstraintOption
+		want        string
+	}{
+		{
+			constraints: []string{">=1.0.0, >=1.2.0, <3.0.0 || ^1.5.0", ">=1.2.0 <2.0.0 || 2.x", "~1.2 || >=1.3.0, <3.0.0-beta"},
+			want:        ">=1.2.0,<3.0.0",
+		},
+		{
+			constraints: []string{">1.2.3", ">=1.2.4", ">=1.2.4-alpha, >=1.2.3"},
+			want:        ">=1.2.4",
+		},
+		{
+			constraints: []string{"1.2.3", "=1.2.3+build", ">1.2.2 <1.2.4", ">=1.2.3-0 <=1.2.3"},
+			want:        "=1.2.3",
+		},
+		{
+			constraints: []string{"^1.2.3-beta", "^1.2.3-beta || ^1.2.3"},
+			want:        ">=1.2.3,<2.0.0||>=1.2.3-beta,<2.0.1-0",
+		},
+		{
+			constraints: []string{">2.0.0, <1.0.0", "<0.0.0-0", "1.x-beta", ">1.2.3 <1.2.4"},
+			want:        "<0.0.0-0",
+		},
+		{
+			constraints: []string{">=1.2, <2 || ^1.5.0", "^1.2.0-0, <2.0.0", ">=1.2.0-0 <2.0.0"},
+			opts:        []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want:        ">=1.2.0-0,<2.0.0",
+		},
+		{
+			constraints: []string{">1.2.3", ">=1.2.4-0", ">1.2.3+build"},
+			opts:        []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want:        ">1.2.3",
+		},
+		{
+			constraints: []string{"<=1.2.3-beta", "<1.2.3-beta.0"},
+			opts:        []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want:        "<=1.2.3-beta",
+		},
+		{
+			constraints: []string{"*", ">=0.0.0-0", "<1.0.0 || >=1.0.0-0"},
+			opts:        []semver.ConstraintOption{semver.WithPreRelease(true)},
+			want:        "*",
+		},
+		{
+			constraints: []string{">=1.2.3-beta <1.2.4", ">=1.2.3-beta, <=1.2.3-rc || >1.2.3-rc <1.2.4-0 || 1.2.3"},
+			opts:        []semver.ConstraintOption{semver.WithNpmPrereleaseSemantics(true)},
+			want:        "=1.2.3||>=1.2.3-beta,<1.2.4-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			for _, constraint := range tt.constraints {
+				c, err := semver.NewConstraints(constraint, tt.opts...)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, c.Canonical(), constraint)
+			}
+		})
+	}
+}
+
+func TestConstraints_SimplifyCheck(t *testing.T) {
+	constraints := []string{
+		"*", "1.2.3", "1.x", "!=1.2.3", ">1.2.3", ">=1.2.3-beta", "<1.2.3", "<=1.2", "<1.2.3-beta.2",
+		"~1.2.3", "~1", "^1.2.3", "^0.0.3", "^1.2.3-beta.2", "1.2.3-x", "=1.2.3-beta", "!=1.2.3-beta",
+		">=1.2.3, <2.0.0", "^1.2.3, !=1.5.0", "1.2.3 - 2.3", "1.0.0-alpha - 1.2.3-beta", "<0.0.1",
+		"^1.2 || ~2.3.4, != 2.3.5", ">=2.0.0 || 1.x || <0.5.0", ">=1.0.0-0 <=1.5.0-rc.1 || >2.0.0",
+		">=1.0.0, >=1.2.0, <3.0.0 || ^1.5.0", ">1.2.3-beta <=1.2.3 || >=1.2.4-0 <1.3.0",
+	}
+	versions := []string{
+		"0.0.0-0", "0.0.0", "0.0.1-0", "0.0.1", "0.0.3", "0.0.4-0", "0.1.0", "0.9.9", "1.0.0-0", "1.0.0-alpha",
+		"1.0.0", "1.2.0-0", "1.2.0", "1.2.3-alpha", "1.2.3-beta", "1.2.3-beta.0", "1.2.3-beta.2",
+		"1.2.3-beta.10", "1.2.3", "1.2.4-0", "1.2.4", "1.3.0-0", "1.3.0", "1.5.0-rc.1", "1.5.0-rc.2",
+		"1.5.0", "1.9.9", "2.0.0-0", "2.0.0-rc.1", "2.0.0", "2.3.4", "2.3.5", "2.4.0-0", "2.4.0", "3.0.0",
+		"10.0.0",
+	}
+	for _, opts := range [][]semver.ConstraintOption{
+		{},
+		{semver.WithPreRelease(true)},
+		{semver.WithNpmPrereleaseSemantics(true)},
+		{semver.WithZeroPadding(true)},
+	} {
+		for _, s1 := range constraints {
+			c1, err := semver.NewConstraints(s1, opts...)
+			require.NoError(t, err)
+
+			simplified := c1.Simplify()
+			parsed, err := semver.NewConstraints(simplified.String(), opts...)
+			require.NoError(t, err, simplified.String())
+			for _, s := range versions {
+				v := semver.MustParse(s)
+				assert.Equal(t, c1.Check(v), simplified.Check(v), "%s vs %s (%s)", s1, s, simplified)
+				assert.Equal(t, c1.Check(v), parsed.Check(v), "%s vs %s (%s)", s1, s, simplified)
+			}
+
+			for _, s2 := range constraints {
+				c2, err := semver.NewConstraints(s2, opts...)
+				require.NoError(t, err)
+				if c1.Canonical() != c2.Canonical() {
+					continue
+				}
+				for _, s := range versions {
+					v := semver.MustParse(s)
+					assert.Equal(t, c1.Check(v), c2.Check(v), "%s vs %s (%s)", s1, s2, c1.Canonical())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package semver
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+	"github.com/aquasecurity/go-version/pkg/prerelease"
+)
+
+// SortKey returns a byte string whose lexicographic order equals Compare order,
+// so that versions can be stored as keys in key-value stores and range-scanned.
+// Build metadata is ignored as well as Compare. Wildcards are encoded as 0.
+func (v Version) SortKey() []byte {
+	b := make([]byte, 0, 32)
+	for _, p := range []part.Part{v.major, v.minor, v.patch} {
+		n, _ := p.(part.Uint64)
+		b = part.AppendUint64Key(b, n)
+	}
+	return prerelease.AppendSortKey(b, v.preRelease)
+}
+
+// ParseSortKey decodes a key returned by SortKey.
+// Build metadata is not restored, and Original() returns String().
+func ParseSortKey(key []byte) (Version, error) {
+	var nums [3]part.Uint64
+	var err error
+	for i := range nums {
+		if nums[i], key, err = part.ReadUint64Key(key); err != nil {
+			return Version{}, xerrors.Errorf("invalid sort key: %w", err)
+		}
+	}
+
+	pre, key, err := prerelease.ReadSortKey(key)
+	if err != nil {
+		return Version{}, xerrors.Errorf("invalid sort key: %w", err)
+	} else if len(key) != 0 {
+		return Version{}, xerrors.New("invalid sort key: trailing data")
+	}
+
+	v := New(nums[0], nums[1], nums[2], pre, "")
+	v.original = v.String()
+	return v, nil
+}
@@ -0,0 +1,84 @@
+package semver_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+var sortKeyVersions = []string{
+	"0.0.0",
+	"0.0.1",
+	"0.1.0",
+	"1.0.0-0",
+	"1.0.0-1",
+	"1.0.0-2",
+	"1.0.0-10",
+	"1.0.0-256",
+	"1.0.0-a",
+	"1.0.0-alpha",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha.beta",
+	"1.0.0-beta",
+	"1.0.0-beta.2",
+	"1.0.0-beta.11",
+	"1.0.0-rc.1",
+	"1.0.0-rc.1+build.1",
+	"1.0.0-18446744073709551616",
+	"1.0.0",
+	"1.0.0+build",
+	"1.0.1",
+	"1.2.255",
+	"1.2.256",
+	"1.10.0",
+	"255.0.0",
+	"256.0.0",
+	"18446744073709551615.0.0",
+}
+
+func TestVersion_SortKey(t *testing.T) {
+	versions := make([]semver.Version, len(sortKeyVersions))
+	for i, s := range sortKeyVersions {
+		v, err := semver.Parse(s)
+		require.NoError(t, err)
+		versions[i] = v
+	}
+
+	for _, v1 := range versions {
+		for _, v2 := range versions {
+			t.Run(fmt.Sprintf("%s vs %s", v1, v2), func(t *testing.T) {
+				assert.Equal(t, v1.Compare(v2), bytes.Compare(v1.SortKey(), v2.SortKey()))
+			})
+		}
+	}
+}
+
+func TestParseSortKey(t *testing.T) {
+	for _, s := range sortKeyVersions {
+		t.Run(s, func(t *testing.T) {
+			v, err := semver.Parse(s)
+			require.NoError(t, err)
+
+			got, err := semver.ParseSortKey(v.SortKey())
+			require.NoError(t, err)
+			assert.Equal(t, v.Major(), got.Major())
+			assert.Equal(t, v.Minor(), got.Minor())
+			assert.Equal(t, v.Patch(), got.Patch())
+			assert.Equal(t, v.PreRelease(), got.PreRelease())
+			assert.Equal(t, 0, v.Compare(got))
+			assert.Empty(t, got.Metadata())
+		})
+	}
+
+	t.Run("broken", func(t *testing.T) {
+		for _, key := range [][]byte{nil, {0, 0}, {0, 0, 0}, {0, 0, 0, 0x09}, {0, 0, 0, 0x03, 0x00}, {9}} {
+			_, err := semver.ParseSortKey(key)
+			assert.Error(t, err, key)
+		}
+	})
+}
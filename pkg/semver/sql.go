@@ -0,0 +1,101 @@
+package semver
+
+import (
+	"database/sql/driver"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/internal/codec"
+)
+
+// Scan implements sql.Scanner. It accepts a string or []byte column.
+// Use NullVersion for nullable columns.
+func (v *Version) Scan(src any) error {
+	s, err := codec.ScanString(src)
+	if err != nil {
+		return xerrors.Errorf("unable to scan version: %w", err)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Value implements driver.Valuer. The version is stored as String().
+// It returns an error in the same cases as MarshalText.
+func (v Version) Value() (driver.Value, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// NullVersion represents a Version that may be null.
+// It works like sql.NullString.
+type NullVersion struct {
+	Version Version
+	Valid   bool // Valid is true if Version is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullVersion) Scan(src any) error {
+	if src == nil {
+		n.Version, n.Valid = Version{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Version.Scan(src)
+}
+
+// Value implements driver.Valuer.
+func (n NullVersion) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Version.Value()
+}
+
+// Scan implements sql.Scanner. It accepts a string or []byte column
+// and parses it without options such as WithPreRelease and WithZeroPadding.
+// Use NullConstraints for nullable columns.
+func (cs *Constraints) Scan(src any) error {
+	s, err := codec.ScanString(src)
+	if err != nil {
+		return xerrors.Errorf("unable to scan constraints: %w", err)
+	}
+
+	c, err := NewConstraints(s)
+	if err != nil {
+		return err
+	}
+	*cs = c
+	return nil
+}
+
+// Value implements driver.Valuer. The constraints are stored as String().
+func (cs Constraints) Value() (driver.Value, error) {
+	return cs.String(), nil
+}
+
+// NullConstraints represents Constraints that may be null.
+// It works like sql.NullString.
+type NullConstraints struct {
+	Constraints Constraints
+	Valid       bool // Valid is true if Constraints is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullConstraints) Scan(src any) error {
+	if src == nil {
+		n.Constraints, n.Valid = Constraints{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Constraints.Scan(src)
+}
+
+// Value implements driver.Valuer.
+func (n NullConstraints) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Constraints.Value()
+}
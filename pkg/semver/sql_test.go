@@ -0,0 +1,94 @@
+package semver_test
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+)
+
+func TestVersion_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    string
+		wantErr bool
+	}{
+		{name: "string", src: "1.2.3-beta+build", want: "1.2.3-beta+build"},
+		{name: "bytes", src: []byte("1.2.3"), want: "1.2.3"},
+		{name: "NULL", src: nil, wantErr: true},
+		{name: "integer", src: int64(1), wantErr: true},
+		{name: "invalid", src: "1.2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got semver.Version
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+
+			value, err := got.Value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
+
+func TestNullVersion(t *testing.T) {
+	var got semver.NullVersion
+	require.NoError(t, got.Scan("1.2.3"))
+	assert.True(t, got.Valid)
+	assert.Equal(t, "1.2.3", got.Version.String())
+
+	value, err := got.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("1.2.3"), value)
+
+	require.NoError(t, got.Scan(nil))
+	assert.False(t, got.Valid)
+	assert.Equal(t, semver.Version{}, got.Version)
+
+	value, err = got.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestConstraints_Scan(t *testing.T) {
+	var got semver.Constraints
+	require.NoError(t, got.Scan([]byte(">= 1.2.3, < 2.0.0 || ^3.1")))
+
+	v, err := semver.Parse("3.4.0")
+	require.NoError(t, err)
+	assert.True(t, got.Check(v))
+
+	value, err := got.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value(">= 1.2.3,< 2.0.0||^3.1"), value)
+
+	assert.Error(t, got.Scan(nil))
+	assert.Error(t, got.Scan(">> 1.0"))
+}
+
+func TestNullConstraints(t *testing.T) {
+	var got semver.NullConstraints
+	require.NoError(t, got.Scan("~1.2"))
+	assert.True(t, got.Valid)
+
+	value, err := got.Value()
+	require.NoError(t, err)
+	assert.Equal(t, driver.Value("~1.2"), value)
+
+	require.NoError(t, got.Scan(nil))
+	assert.False(t, got.Valid)
+
+	value, err = got.Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
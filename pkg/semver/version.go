@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
-	"regexp"
+	"strings"
 
 	"golang.org/x/xerrors"
 
@@ -12,23 +12,6 @@ import (
 	"github.com/aquasecurity/go-version/pkg/prerelease"
 )
 
-var (
-	// ErrInvalidSemVer is returned when a given version is invalid
-	ErrInvalidSemVer = xerrors.New("invalid semantic version")
-)
-
-var versionRegex *regexp.Regexp
-
-// regex is the regular expression used to parse a SemVer string.
-// See: https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
-const regex string = `^(?P<major>0|[1-9]\d*)\.(?P<minor>0|[1-9]\d*)\.(?P<patch>0|[1-9]\d*)` +
-	`(?:-(?P<prerelease>(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))` +
-	`?(?:\+(?P<buildmetadata>[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
-
-func init() {
-	versionRegex = regexp.MustCompile(regex)
-}
-
 // Version represents a semantic version.
 type Version struct {
 	major, minor, patch part.Part
@@ -48,45 +31,59 @@ func New(major, minor, patch part.Part, pre part.Parts, metadata string) Version
 	}
 }
 
-// Parse parses a given version and returns a new instance of Version
-func Parse(v string) (Version, error) {
-	m := versionRegex.FindStringSubmatch(v)
-	if m == nil {
-		return Version{}, ErrInvalidSemVer
-	}
-
-	major, err := part.NewUint64(m[versionRegex.SubexpIndex("major")])
-	if err != nil {
-		return Version{}, xerrors.Errorf("invalid major version: %w", err)
-	}
-
-	minor, err := part.NewUint64(m[versionRegex.SubexpIndex("minor")])
-	if err != nil {
-		return Version{}, xerrors.Errorf("invalid minor version: %w", err)
+// NewFromUint64 returns an instance of Version from numbers.
+// e.g. NewFromUint64(1, 2, 3) => 1.2.3
+func NewFromUint64(major, minor, patch uint64) Version {
+	v := Version{
+		major: part.Uint64(major),
+		minor: part.Uint64(minor),
+		patch: part.Uint64(patch),
 	}
+	v.original = v.String()
+	return v
+}
 
-	patch, err := part.NewUint64(m[versionRegex.SubexpIndex("patch")])
+// MustParse works like Parse, but panics if the version cannot be parsed.
+func MustParse(v string, opts ...ParseOption) Version {
+	ver, err := Parse(v, opts...)
 	if err != nil {
-		return Version{}, xerrors.Errorf("invalid patch version: %w", err)
+		panic(err)
 	}
+	return ver
+}
 
-	return Version{
-		major:         major,
-		minor:         minor,
-		patch:         patch,
-		preRelease:    part.NewParts(m[versionRegex.SubexpIndex("prerelease")]),
-		buildMetadata: m[versionRegex.SubexpIndex("buildmetadata")],
-		original:      v,
-	}, nil
+// Parse parses a given version and returns a new instance of Version
+func Parse(v string, opts ...ParseOption) (Version, error) {
+	return parse(v, newParseConf(opts))
 }
 
 // String converts a Version object to a string.
+// Wildcards and missing parts in versions of constraints are kept, e.g. *, 1.x, 2 and 1.2.3-x.
 func (v Version) String() string {
 	var buf bytes.Buffer
 
-	fmt.Fprintf(&buf, "%d.%d.%d", v.major, v.minor, v.patch)
-	if !v.preRelease.IsNull() {
-		fmt.Fprintf(&buf, "-%s", v.preRelease)
+	if v.major != nil && v.major.IsAny() {
+		// e.g. * or x.x.x
+		buf.WriteString("*")
+	} else {
+		for i, p := range []part.Part{v.major, v.minor, v.patch} {
+			if p == nil || p.IsEmpty() {
+				// e.g. 1 or 1.2
+				break
+			} else if i > 0 {
+				buf.WriteByte('.')
+			}
+			if p.IsAny() {
+				// e.g. 1.x
+				buf.WriteString("x")
+				continue
+			}
+			buf.WriteString(p.String())
+		}
+	}
+	// The wildcard pre-release of a wildcard version such as 1.x is implied
+	if len(v.preRelease) > 0 && !(v.preRelease.IsAny() && v.major != nil && v.IsAny()) {
+		buf.WriteString("-" + preReleaseString(v.preRelease))
 	}
 	if v.buildMetadata != "" {
 		fmt.Fprintf(&buf, "+%s", v.buildMetadata)
@@ -95,6 +92,79 @@ func (v Version) String() string {
 	return buf.String()
 }
 
+// preReleaseString shows a wildcard in the pre-release as "x" so that the string can be parsed again, e.g. 1.2.3-x.1
+func preReleaseString(pre part.Parts) string {
+	ss := make([]string, len(pre))
+	for i, p := range pre {
+		if p.IsAny() {
+			ss[i] = "x"
+			continue
+		}
+		ss[i] = p.String()
+	}
+	return strings.Join(ss, ".")
+}
+
+// lexicalPreRelease tests if the pre-release is a single identifier parsed with WithSemVer1(true)
+// that Semantic Versioning 2.0.0 parses differently or rejects, e.g. 1.0.0-9, 1.0.0-01 or 1.0.0-x.
+func (v Version) lexicalPreRelease() bool {
+	if len(v.preRelease) != 1 {
+		return false
+	}
+	s, ok := v.preRelease[0].(part.String)
+	return ok && part.NewPart(string(s)) != part.Part(s)
+}
+
+// WithMajor returns a copy of the version with the given major version.
+func (v Version) WithMajor(major uint64) Version {
+	v.major = part.Uint64(major)
+	v.original = v.String()
+	return v
+}
+
+// WithMinor returns a copy of the version with the given minor version.
+func (v Version) WithMinor(minor uint64) Version {
+	v.minor = part.Uint64(minor)
+	v.original = v.String()
+	return v
+}
+
+// WithPatch returns a copy of the version with the given patch version.
+func (v Version) WithPatch(patch uint64) Version {
+	v.patch = part.Uint64(patch)
+	v.original = v.String()
+	return v
+}
+
+// WithPreRelease returns a copy of the version with the given pre-release.
+// e.g. 1.2.3 => 1.2.3-rc.1 with "rc.1"
+// An empty string removes the pre-release. It returns an error if the pre-release is invalid.
+func (v Version) WithPreRelease(preRelease string) (Version, error) {
+	v.preRelease = nil
+	if preRelease != "" {
+		if err := validateIdentifiers(preRelease, "prerelease", true); err != nil {
+			return Version{}, err
+		}
+		v.preRelease = part.NewParts(preRelease)
+	}
+	v.original = v.String()
+	return v, nil
+}
+
+// WithMetadata returns a copy of the version with the given build metadata.
+// e.g. 1.2.3 => 1.2.3+build.5 with "build.5"
+// An empty string removes the build metadata. It returns an error if the build metadata is invalid.
+func (v Version) WithMetadata(metadata string) (Version, error) {
+	if metadata != "" {
+		if err := validateIdentifiers(metadata, "buildmetadata", false); err != nil {
+			return Version{}, err
+		}
+	}
+	v.buildMetadata = metadata
+	v.original = v.String()
+	return v, nil
+}
+
 // IsAny returns true if major, minor or patch is wild card
 func (v Version) IsAny() bool {
 	return v.major.IsAny() || v.minor.IsAny() || v.patch.IsAny()
@@ -107,8 +177,98 @@ func (v Version) Release() Version {
 	return v
 }
 
+// Level represents major, minor or patch.
+type Level int
+
+const (
+	LevelMajor Level = iota
+	LevelMinor
+	LevelPatch
+
+	// levelNone means no level is incremented
+	levelNone Level = -1
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelMajor:
+		return "major"
+	case LevelMinor:
+		return "minor"
+	case LevelPatch:
+		return "patch"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// Bump produces the next version at the given level.
+// e.g. 1.2.3 => 2.0.0 (major), 1.3.0 (minor) or 1.2.4 (patch)
+// Missing parts are treated as 0, e.g. 1 => 1.1.0 (minor).
+// It returns ErrNotNumeric if the part or a higher part is a wildcard,
+// and ErrOverflow if the part is the maximum uint64.
+func (v Version) Bump(level Level) (Version, error) {
+	if level < LevelMajor || level > LevelPatch {
+		return Version{}, xerrors.Errorf("unknown level: %s", level)
+	}
+
+	parts := []*part.Part{&v.major, &v.minor, &v.patch}
+	for i, p := range parts {
+		l := Level(i)
+		if l > level {
+			*p = part.Zero
+			continue
+		}
+
+		var n part.Uint64
+		switch t := (*p).(type) {
+		case part.Uint64:
+			n = t
+		case part.Empty:
+			n = part.Zero
+		default:
+			return Version{}, xerrors.Errorf("%s version %w", l, ErrNotNumeric)
+		}
+
+		if l == level {
+			if n == math.MaxUint64 {
+				return Version{}, xerrors.Errorf("%s version %w", l, ErrOverflow)
+			}
+			n++
+		}
+		*p = n
+	}
+
+	v.preRelease = part.Parts{}
+	v.buildMetadata = ""
+	v.original = v.String()
+	return v, nil
+}
+
+// bump works like Bump, but carries an overflow to the higher part, e.g. 0.0.MAX => 0.1.0,
+// and returns an unbounded version greater than any other version if the major version overflows
+// or the part cannot be incremented.
+func (v Version) bump(level Level) Version {
+	for {
+		bumped, err := v.Bump(level)
+		switch {
+		case err == nil:
+			return bumped
+		case xerrors.Is(err, ErrOverflow) && level > LevelMajor:
+			level--
+		default:
+			return Version{
+				major:      part.Infinity,
+				minor:      part.Zero,
+				patch:      part.Zero,
+				preRelease: part.Parts{},
+			}
+		}
+	}
+}
+
 // IncMajor produces the next major version.
 // e.g. 1.2.3 => 2.0.0
+// It panics if the major version is not a number. Use Bump to handle errors.
 func (v Version) IncMajor() Version {
 	v.major = v.major.(part.Uint64) + 1
 	v.minor = part.Zero
@@ -120,6 +280,7 @@ func (v Version) IncMajor() Version {
 }
 
 // IncMinor produces the next minor version.
+// It panics if the minor version is not a number. Use Bump to handle errors.
 func (v Version) IncMinor() Version {
 	v.minor = v.minor.(part.Uint64) + 1
 	v.patch = part.Zero
@@ -130,6 +291,7 @@ func (v Version) IncMinor() Version {
 }
 
 // IncPatch produces the next patch version.
+// It panics if the patch version is not a number. Use Bump to handle errors.
 func (v Version) IncPatch() Version {
 	v.patch = v.patch.(part.Uint64) + 1
 	v.preRelease = part.Parts{}
@@ -138,6 +300,123 @@ func (v Version) IncPatch() Version {
 	return v
 }
 
+// IncPreMajor produces the next major pre-release version like `npm version premajor`.
+// e.g. 1.2.3 => 2.0.0-0, 2.0.0-rc.0 with WithIdentifier("rc")
+func (v Version) IncPreMajor(opts ...PreReleaseOption) (Version, error) {
+	return v.incPre(LevelMajor, opts)
+}
+
+// IncPreMinor produces the next minor pre-release version like `npm version preminor`.
+// e.g. 1.2.3 => 1.3.0-0, 1.3.0-rc.0 with WithIdentifier("rc")
+func (v Version) IncPreMinor(opts ...PreReleaseOption) (Version, error) {
+	return v.incPre(LevelMinor, opts)
+}
+
+// IncPrePatch produces the next patch pre-release version like `npm version prepatch`.
+// e.g. 1.2.3 => 1.2.4-0, 1.2.4-rc.0 with WithIdentifier("rc")
+func (v Version) IncPrePatch(opts ...PreReleaseOption) (Version, error) {
+	return v.incPre(LevelPatch, opts)
+}
+
+// IncPreRelease produces the next pre-release version like `npm version prerelease`.
+// It increments the last numeric identifier of the pre-release, or works like IncPrePatch for a release.
+// e.g. 1.2.4-rc.0 => 1.2.4-rc.1, 1.2.4-rc => 1.2.4-rc.0, 1.2.3 => 1.2.4-0
+// With WithIdentifier, the pre-release starts over if the identifier is changed.
+// e.g. 1.2.4-alpha.3 => 1.2.4-beta.0 with WithIdentifier("beta")
+func (v Version) IncPreRelease(opts ...PreReleaseOption) (Version, error) {
+	if !v.IsPreRelease() {
+		return v.incPre(LevelPatch, opts)
+	}
+	return v.incPre(levelNone, opts)
+}
+
+// incPre increments the version at the given level if any, and then the pre-release.
+// ref. https://github.com/npm/node-semver/blob/v7.6.3/classes/semver.js#L177-L290
+func (v Version) incPre(level Level, opts []PreReleaseOption) (Version, error) {
+	c := new(preReleaseConf)
+	for _, o := range opts {
+		o.applyPreRelease(c)
+	}
+
+	var identifier part.Parts
+	if c.identifier != "" {
+		if err := validateIdentifiers(c.identifier, "prerelease", true); err != nil {
+			return Version{}, xerrors.Errorf("invalid identifier: %w", err)
+		}
+		identifier = part.NewParts(c.identifier)
+	} else if c.noBase {
+		return Version{}, xerrors.New("identifier is empty")
+	}
+
+	if level != levelNone {
+		var err error
+		if v, err = v.Bump(level); err != nil {
+			return Version{}, err
+		}
+	}
+
+	base := part.Uint64(c.base)
+	pre := make(part.Parts, len(v.preRelease), len(v.preRelease)+len(identifier)+1)
+	copy(pre, v.preRelease)
+	if len(pre) == 0 {
+		pre = append(pre, base)
+	} else if i := lastNumeric(pre); i >= 0 {
+		n := pre[i].(part.Uint64)
+		if n == math.MaxUint64 {
+			return Version{}, xerrors.Errorf("pre-release %w", ErrOverflow)
+		}
+		pre[i] = n + 1
+	} else {
+		if c.noBase && pre.String() == c.identifier {
+			return Version{}, xerrors.Errorf("identifier already exists: %s", c.identifier)
+		}
+		pre = append(pre, base)
+	}
+
+	// Start over if the identifier is changed or not followed by a number
+	// e.g. alpha.1 => beta.0, alpha.beta => alpha.0
+	if len(identifier) > 0 && (!hasPrefix(pre, identifier) || !isNumeric(pre, len(identifier))) {
+		pre = append(part.Parts{}, identifier...)
+		if !c.noBase {
+			pre = append(pre, base)
+		}
+	}
+
+	v.preRelease = pre
+	v.buildMetadata = ""
+	v.original = v.String()
+	return v, nil
+}
+
+func lastNumeric(parts part.Parts) int {
+	for i := len(parts) - 1; i >= 0; i-- {
+		if _, ok := parts[i].(part.Uint64); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasPrefix(parts, prefix part.Parts) bool {
+	if len(parts) < len(prefix) {
+		return false
+	}
+	for i, p := range prefix {
+		if parts[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(parts part.Parts, i int) bool {
+	if i >= len(parts) {
+		return false
+	}
+	_, ok := parts[i].(part.Uint64)
+	return ok
+}
+
 // Min produces the minimum version if it includes wild card.
 // 1.2.* => 1.2.0
 // 1.*.* => 1.0.0
@@ -248,6 +527,20 @@ func (v Version) Compare(o Version) int {
 	return prerelease.Compare(v.preRelease, o.preRelease)
 }
 
+// CompareStrict compares this version to another one like Compare, but breaks ties
+// by build metadata and then by Original(), so that it returns 0 only if both are
+// literally the same. Use it for deterministic sorting rather than for precedence.
+// e.g. 1.0.0 < 1.0.0+2 < 1.0.0+10 < 1.0.0+build < 1.0.0+build.1
+func (v Version) CompareStrict(o Version) int {
+	if result := v.Compare(o); result != 0 {
+		return result
+	}
+	if result := part.CompareBuildMetadata(v.buildMetadata, o.buildMetadata); result != 0 {
+		return result
+	}
+	return strings.Compare(v.original, o.original)
+}
+
 // TildeBump returns the maximum version of tilde ranges
 // e.g. ~1.2.3 := >=1.2.3 <1.3.0
 // In this case, it returns 1.3.0
@@ -259,13 +552,13 @@ func (v Version) TildeBump() Version {
 		return v
 	case v.minor.IsAny(), v.minor.IsEmpty():
 		// e.g. 1 => 2.0.0
-		return v.IncMajor()
+		return v.bump(LevelMajor)
 	case v.patch.IsAny(), v.patch.IsEmpty():
 		// e.g. 1.2 => 1.3.0
-		return v.IncMinor()
+		return v.bump(LevelMinor)
 	default:
 		// e.g. 1.2.3 => 1.3.0
-		return v.IncMinor()
+		return v.bump(LevelMinor)
 	}
 }
 
@@ -278,20 +571,20 @@ func (v Version) CaretBump() Version {
 	case v.major.IsAny(), v.major.IsEmpty():
 		v.major = part.Uint64(math.MaxUint64)
 		return v
-	case v.major.(part.Uint64) != 0:
+	case v.major != part.Zero:
 		// e.g. 1 => 2.0.0
-		return v.IncMajor()
+		return v.bump(LevelMajor)
 	case v.minor.IsAny(), v.minor.IsEmpty():
 		// e.g. 0 => 1.0.0
-		return v.IncMajor()
-	case v.minor.(part.Uint64) != 0:
+		return v.bump(LevelMajor)
+	case v.minor != part.Zero:
 		// e.g. 0.2.3 => 0.3.0
-		return v.IncMinor()
+		return v.bump(LevelMinor)
 	case v.patch.IsAny(), v.patch.IsEmpty():
 		// e.g. 0.0 => 0.1.0
-		return v.IncMinor()
+		return v.bump(LevelMinor)
 	default:
 		// e.g. 0.0.3 => 0.0.4
-		return v.IncPatch()
+		return v.bump(LevelPatch)
 	}
 }
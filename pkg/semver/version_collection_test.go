@@ -80,3 +80,65 @@ func TestCollection(t *testing.T) {
 	}
 
 }
+
+func TestStrictCollection(t *testing.T) {
+	versions := []string{
+		"1.0.0+build.1",
+		"v1.0.0",
+		"1.0.0+10",
+		"1.0.0-rc.1+b",
+		"1.0.0+build",
+		"1.0.0",
+		"1.0.0+2",
+		"1.0.0+002",
+		"1.0.0-rc.1+a",
+		"0.9.0+z",
+	}
+	want := []string{
+		"0.9.0+z",
+		"1.0.0-rc.1+a",
+		"1.0.0-rc.1+b",
+		"1.0.0",
+		"v1.0.0",
+		"1.0.0+002",
+		"1.0.0+2",
+		"1.0.0+10",
+		"1.0.0+build",
+		"1.0.0+build.1",
+	}
+
+	// Every permutation must be sorted into the same order
+	for _, shift := range []int{0, 3, 7} {
+		vs := make([]semver.Version, len(versions))
+		for i := range versions {
+			v, err := semver.Parse(versions[(i+shift)%len(versions)], semver.WithVPrefix(true))
+			require.NoError(t, err)
+			vs[i] = v
+		}
+
+		sort.Sort(semver.StrictCollection(vs))
+
+		got := make([]string, len(vs))
+		for i, v := range vs {
+			got[i] = v.Original()
+		}
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	versions := make([]semver.Version, 0, 5)
+	for _, raw := range []string{"1.0.0+b", "0.1.0", "1.0.0+c", "1.0.0+a", "1.0.0-rc.1"} {
+		v, err := semver.Parse(raw)
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+
+	semver.SortStable(versions)
+
+	got := make([]string, len(versions))
+	for i, v := range versions {
+		got[i] = v.Original()
+	}
+	assert.Equal(t, []string{"0.1.0", "1.0.0-rc.1", "1.0.0+b", "1.0.0+c", "1.0.0+a"}, got)
+}
@@ -2,11 +2,13 @@ package semver_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aquasecurity/go-version/pkg/part"
 	"github.com/aquasecurity/go-version/pkg/semver"
 )
 
@@ -88,3 +90,421 @@ func TestVersion_Compare(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		version   string
+		offset    int
+		component string
+		reason    string
+	}{
+		{"", 0, "major", "empty version"},
+		{"1", 1, "major", "expected '.' after major"},
+		{"1.", 2, "minor", "missing minor"},
+		{"v1.2.3", 0, "major", `unexpected character 'v' in major`},
+		{"1.02.3", 2, "minor", "leading zero in minor"},
+		{"1.2.beta", 4, "patch", `unexpected character 'b' in patch`},
+		{"1.2.3-", 6, "prerelease", "empty prerelease identifier"},
+		{"1.2.3-alpha..1", 12, "prerelease", "empty prerelease identifier"},
+		{"1.2.3-alpha.01", 12, "prerelease", "leading zero in prerelease identifier"},
+		{"1.2.3+build.", 12, "buildmetadata", "empty buildmetadata identifier"},
+		{"1.2.3.4", 5, "patch", `unexpected character '.'`},
+		{"1.2.3-beta_1", 10, "prerelease", `unexpected character '_'`},
+		{"1.2.18446744073709551616", 4, "patch", "invalid patch version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			_, err := semver.Parse(tt.version)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, semver.ErrInvalidSemVer)
+
+			var perr *semver.ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.version, perr.Input)
+			assert.Equal(t, tt.offset, perr.Offset)
+			assert.Equal(t, tt.component, perr.Component)
+			assert.Equal(t, tt.reason, perr.Reason)
+		})
+	}
+}
+
+func TestParse_Options(t *testing.T) {
+	tests := []struct {
+		version string
+		opts    []semver.ParseOption
+		want    string
+		reason  string
+	}{
+		{version: "v1.2.3", opts: []semver.ParseOption{semver.WithVPrefix(true)}, want: "1.2.3"},
+		{version: "v1.2.3", opts: []semver.ParseOption{semver.WithVPrefix(false)}, reason: `unexpected character 'v' in major`},
+		{version: "vv1.2.3", opts: []semver.ParseOption{semver.WithVPrefix(true)}, reason: `unexpected character 'v' in major`},
+		{version: "1.2.3-beta.01", opts: []semver.ParseOption{semver.WithSemVer1(true)}, reason: `unexpected character '.'`},
+		{version: "1.2.3-beta01", opts: []semver.ParseOption{semver.WithSemVer1(true)}, want: "1.2.3-beta01"},
+		{version: "1.2.3+build", opts: []semver.ParseOption{semver.WithSemVer1(true)}, reason: `unexpected character '+'`},
+		{version: "1.2.3-alpha", opts: []semver.ParseOption{semver.WithMaxLength(11)}, want: "1.2.3-alpha"},
+		{version: "1.2.3-alpha", opts: []semver.ParseOption{semver.WithMaxLength(10)}, reason: "longer than 10 bytes"},
+		{version: "1.2.3-a.b.c", opts: []semver.ParseOption{semver.WithMaxPreReleaseIdentifiers(3)}, want: "1.2.3-a.b.c"},
+		{version: "1.2.3-a.b.c.d", opts: []semver.ParseOption{semver.WithMaxPreReleaseIdentifiers(3)}, reason: "more than 3 prerelease identifiers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := semver.Parse(tt.version, tt.opts...)
+			if tt.reason != "" {
+				var perr *semver.ParseError
+				require.ErrorAs(t, err, &perr)
+				assert.Equal(t, tt.reason, perr.Reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.version, got.Original())
+		})
+	}
+}
+
+func TestVersion_CompareSemVer1(t *testing.T) {
+	cases := []struct {
+		v1       string
+		v2       string
+		expected int
+	}{
+		// Pre-releases are compared in ASCII sort order
+		{"1.0.0-beta10", "1.0.0-beta2", -1},
+		{"1.0.0-10", "1.0.0-9", -1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0-rc1", "1.0.0-rc1", 0},
+	}
+
+	for _, tt := range cases {
+		t.Run(fmt.Sprintf("%s vs %s", tt.v1, tt.v2), func(t *testing.T) {
+			v1, err := semver.Parse(tt.v1, semver.WithSemVer1(true))
+			require.NoError(t, err, tt.v1)
+
+			v2, err := semver.Parse(tt.v2, semver.WithSemVer1(true))
+			require.NoError(t, err, tt.v2)
+
+			assert.Equal(t, tt.expected, v1.Compare(v2))
+		})
+	}
+}
+
+func TestVersion_Bump(t *testing.T) {
+	tests := []struct {
+		name    string
+		version semver.Version
+		level   semver.Level
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "major",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.Uint64(3), part.NewParts("beta"), "build"),
+			level:   semver.LevelMajor,
+			want:    "2.0.0",
+		},
+		{
+			name:    "minor",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.Uint64(3), nil, ""),
+			level:   semver.LevelMinor,
+			want:    "1.3.0",
+		},
+		{
+			name:    "patch",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.Uint64(3), nil, ""),
+			level:   semver.LevelPatch,
+			want:    "1.2.4",
+		},
+		{
+			name:    "missing parts",
+			version: semver.New(part.Uint64(1), part.NewEmpty(true), part.NewEmpty(true), nil, ""),
+			level:   semver.LevelPatch,
+			want:    "1.0.1",
+		},
+		{
+			name:    "lower wildcard",
+			version: semver.New(part.Uint64(1), part.Any(true), part.Any(true), nil, ""),
+			level:   semver.LevelMajor,
+			want:    "2.0.0",
+		},
+		{
+			name:    "wildcard",
+			version: semver.New(part.Uint64(1), part.Any(true), part.Any(true), nil, ""),
+			level:   semver.LevelMinor,
+			wantErr: semver.ErrNotNumeric,
+		},
+		{
+			name:    "higher wildcard",
+			version: semver.New(part.Any(true), part.Uint64(2), part.Uint64(3), nil, ""),
+			level:   semver.LevelPatch,
+			wantErr: semver.ErrNotNumeric,
+		},
+		{
+			name:    "overflow",
+			version: semver.New(part.Uint64(1), part.Uint64(math.MaxUint64), part.Uint64(3), nil, ""),
+			level:   semver.LevelMinor,
+			wantErr: semver.ErrOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.version.Bump(tt.level)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.want, got.Original())
+		})
+	}
+}
+
+func TestVersion_IncPre(t *testing.T) {
+	// ref. https://github.com/npm/node-semver/blob/v7.6.3/test/fixtures/increments.js
+	tests := []struct {
+		version string
+		release string
+		opts    []semver.PreReleaseOption
+		want    string
+		wantErr bool
+	}{
+		{version: "1.2.3", release: "premajor", want: "2.0.0-0"},
+		{version: "1.2.3", release: "preminor", want: "1.3.0-0"},
+		{version: "1.2.3", release: "prepatch", want: "1.2.4-0"},
+		{version: "1.2.3", release: "prerelease", want: "1.2.4-0"},
+		{version: "1.2.3-4", release: "prerelease", want: "1.2.3-5"},
+		{version: "1.2.3-alpha.0.beta", release: "prerelease", want: "1.2.3-alpha.1.beta"},
+		{version: "1.2.3-alpha.10.0.beta", release: "prerelease", want: "1.2.3-alpha.10.1.beta"},
+		{version: "1.2.3-alpha.9.beta", release: "prerelease", want: "1.2.3-alpha.10.beta"},
+		{version: "1.2.3-alpha", release: "prerelease", want: "1.2.3-alpha.0"},
+		{version: "1.2.3-4+build", release: "prerelease", want: "1.2.3-5"},
+		{version: "1.2.0-1", release: "premajor", want: "2.0.0-0"},
+		{version: "1.2.0-1", release: "prepatch", want: "1.2.1-0"},
+		{version: "1.2.4", release: "prerelease", opts: []semver.PreReleaseOption{semver.WithIdentifier("dev")}, want: "1.2.5-dev.0"},
+		{version: "1.2.3-dev.2", release: "prerelease", opts: []semver.PreReleaseOption{semver.WithIdentifier("dev")}, want: "1.2.3-dev.3"},
+		{version: "1.2.3-dev", release: "prerelease", opts: []semver.PreReleaseOption{semver.WithIdentifier("dev")}, want: "1.2.3-dev.0"},
+		{version: "1.2.3-alpha.1", release: "prerelease", opts: []semver.PreReleaseOption{semver.WithIdentifier("beta")}, want: "1.2.3-beta.0"},
+		{version: "1.2.3-alpha.beta", release: "prerelease", opts: []semver.PreReleaseOption{semver.WithIdentifier("alpha")}, want: "1.2.3-alpha.0"},
+		{version: "1.2.3", release: "premajor", opts: []semver.PreReleaseOption{semver.WithIdentifier("dev")}, want: "2.0.0-dev.0"},
+		{version: "1.2.3", release: "preminor", opts: []semver.PreReleaseOption{semver.WithIdentifier("dev")}, want: "1.3.0-dev.0"},
+		{version: "1.2.3", release: "prepatch", opts: []semver.PreReleaseOption{semver.WithIdentifier("dev")}, want: "1.2.4-dev.0"},
+		{
+			version: "1.2.3",
+			release: "prerelease",
+			opts:    []semver.PreReleaseOption{semver.WithIdentifier("dev"), semver.WithIdentifierBase(1)},
+			want:    "1.2.4-dev.1",
+		},
+		{
+			version: "1.2.3-dev.1",
+			release: "prerelease",
+			opts:    []semver.PreReleaseOption{semver.WithIdentifier("dev"), semver.WithIdentifierBase(1)},
+			want:    "1.2.3-dev.2",
+		},
+		{
+			version: "1.2.3",
+			release: "prepatch",
+			opts:    []semver.PreReleaseOption{semver.WithIdentifier("dev"), semver.WithoutIdentifierBase(true)},
+			want:    "1.2.4-dev",
+		},
+		{
+			version: "1.2.3-dev",
+			release: "prerelease",
+			opts:    []semver.PreReleaseOption{semver.WithIdentifier("dev"), semver.WithoutIdentifierBase(true)},
+			wantErr: true,
+		},
+		{
+			version: "1.2.3",
+			release: "prerelease",
+			opts:    []semver.PreReleaseOption{semver.WithoutIdentifierBase(true)},
+			wantErr: true,
+		},
+		{
+			version: "1.2.3",
+			release: "prerelease",
+			opts:    []semver.PreReleaseOption{semver.WithIdentifier("dev..1")},
+			wantErr: true,
+		},
+		{version: "1.2.3-18446744073709551615", release: "prerelease", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.release, func(t *testing.T) {
+			v, err := semver.Parse(tt.version)
+			require.NoError(t, err)
+
+			var got semver.Version
+			switch tt.release {
+			case "premajor":
+				got, err = v.IncPreMajor(tt.opts...)
+			case "preminor":
+				got, err = v.IncPreMinor(tt.opts...)
+			case "prepatch":
+				got, err = v.IncPrePatch(tt.opts...)
+			case "prerelease":
+				got, err = v.IncPreRelease(tt.opts...)
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.want, got.Original())
+		})
+	}
+}
+
+func TestNewFromUint64(t *testing.T) {
+	v := semver.NewFromUint64(1, 2, 3)
+	assert.Equal(t, "1.2.3", v.String())
+	assert.Equal(t, "1.2.3", v.Original())
+	assert.True(t, v.Equal(semver.MustParse("1.2.3")))
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Equal(t, "v1.2.3-rc.1", semver.MustParse("v1.2.3-rc.1", semver.WithVPrefix(true)).Original())
+	assert.Panics(t, func() { semver.MustParse("1.2") })
+}
+
+func TestVersion_With(t *testing.T) {
+	v := semver.MustParse("1.2.3-rc.1+build.5")
+
+	tests := []struct {
+		name    string
+		with    func(semver.Version) (semver.Version, error)
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "major",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithMajor(4), nil },
+			want: "4.2.3-rc.1+build.5",
+		},
+		{
+			name: "minor",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithMinor(0), nil },
+			want: "1.0.3-rc.1+build.5",
+		},
+		{
+			name: "patch",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithPatch(math.MaxUint64), nil },
+			want: "1.2.18446744073709551615-rc.1+build.5",
+		},
+		{
+			name: "pre-release",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithPreRelease("beta.0.x-y") },
+			want: "1.2.3-beta.0.x-y+build.5",
+		},
+		{
+			name: "remove pre-release",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithPreRelease("") },
+			want: "1.2.3+build.5",
+		},
+		{
+			name:    "pre-release with leading zero",
+			with:    func(v semver.Version) (semver.Version, error) { return v.WithPreRelease("beta.01") },
+			wantErr: true,
+		},
+		{
+			name:    "pre-release with empty identifier",
+			with:    func(v semver.Version) (semver.Version, error) { return v.WithPreRelease("beta..1") },
+			wantErr: true,
+		},
+		{
+			name:    "pre-release with invalid character",
+			with:    func(v semver.Version) (semver.Version, error) { return v.WithPreRelease("beta+1") },
+			wantErr: true,
+		},
+		{
+			name: "metadata",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithMetadata("sha.0051f85") },
+			want: "1.2.3-rc.1+sha.0051f85",
+		},
+		{
+			name: "remove metadata",
+			with: func(v semver.Version) (semver.Version, error) { return v.WithMetadata("") },
+			want: "1.2.3-rc.1",
+		},
+		{
+			name:    "metadata with invalid character",
+			with:    func(v semver.Version) (semver.Version, error) { return v.WithMetadata("build_5") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.with(v)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, semver.ErrInvalidSemVer)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.want, got.Original())
+			assert.Equal(t, 0, got.Compare(semver.MustParse(tt.want)))
+			assert.Equal(t, "1.2.3-rc.1+build.5", v.String(), "receiver must not be modified")
+		})
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	tests := []struct {
+		name    string
+		version semver.Version
+		want    string
+	}{
+		{
+			name:    "release",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.Uint64(3), nil, ""),
+			want:    "1.2.3",
+		},
+		{
+			name:    "wildcard",
+			version: semver.New(part.Any(true), part.Any(true), part.Any(true), part.Parts{part.Any(true)}, ""),
+			want:    "*",
+		},
+		{
+			name:    "wildcard minor",
+			version: semver.New(part.Uint64(1), part.Any(true), part.NewEmpty(true), nil, ""),
+			want:    "1.x",
+		},
+		{
+			name:    "wildcard patch",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.Any(true), nil, ""),
+			want:    "1.2.x",
+		},
+		{
+			name:    "partial",
+			version: semver.New(part.Uint64(2), part.NewEmpty(false), part.NewEmpty(false), nil, ""),
+			want:    "2",
+		},
+		{
+			name:    "partial with pre-release",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.NewEmpty(true), part.NewParts("beta.1"), ""),
+			want:    "1.2-beta.1",
+		},
+		{
+			name:    "wildcard pre-release",
+			version: semver.New(part.Uint64(1), part.Uint64(2), part.Uint64(3), part.NewParts("X.1"), ""),
+			want:    "1.2.3-x.1",
+		},
+		{
+			name:    "infinity",
+			version: semver.New(part.Infinity, part.Zero, part.Zero, nil, ""),
+			want:    "∞.0.0",
+		},
+		{
+			name: "zero value",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.version.String())
+			assert.Equal(t, tt.want, fmt.Sprint(tt.version))
+		})
+	}
+}
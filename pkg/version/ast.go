@@ -0,0 +1,155 @@
+package version
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Operator represents the operator of a comparator.
+type Operator int
+
+const (
+	OpEqual              Operator = iota // =, == or no operator
+	OpNotEqual                           // !=
+	OpGreaterThan                        // >
+	OpGreaterThanOrEqual                 // >=, =>
+	OpLessThan                           // <
+	OpLessThanOrEqual                    // <=, =<
+	OpTilde                              // ~
+	OpCaret                              // ^
+	OpPessimistic                        // ~>
+)
+
+var operatorKinds = map[string]Operator{
+	"":   OpEqual,
+	"=":  OpEqual,
+	"==": OpEqual,
+	"!=": OpNotEqual,
+	">":  OpGreaterThan,
+	">=": OpGreaterThanOrEqual,
+	"=>": OpGreaterThanOrEqual,
+	"<":  OpLessThan,
+	"<=": OpLessThanOrEqual,
+	"=<": OpLessThanOrEqual,
+	"~>": OpPessimistic,
+	"~":  OpTilde,
+	"^":  OpCaret,
+}
+
+// String returns the canonical notation of the operator, e.g. ">=" for both ">=" and "=>".
+func (o Operator) String() string {
+	switch o {
+	case OpEqual:
+		return "="
+	case OpNotEqual:
+		return "!="
+	case OpGreaterThan:
+		return ">"
+	case OpGreaterThanOrEqual:
+		return ">="
+	case OpLessThan:
+		return "<"
+	case OpLessThanOrEqual:
+		return "<="
+	case OpTilde:
+		return "~"
+	case OpCaret:
+		return "^"
+	case OpPessimistic:
+		return "~>"
+	}
+	return "unknown"
+}
+
+// Comparator is a node of the constraint AST such as ">= 1.2.3".
+type Comparator struct {
+	Operator Operator
+	Version  Version
+
+	// Original is the comparator as written in the constraints, or the comparator
+	// which a hyphen range is expanded to, e.g. ">=1.2.3" for "1.2.3 - 2.3.4".
+	Original string
+}
+
+// String renders the comparator from Operator and Version, e.g. ">=1.2.3".
+func (c Comparator) String() string {
+	return c.Operator.String() + c.Version.String()
+}
+
+func (c Constraint) comparator() Comparator {
+	return Comparator{
+		Operator: operatorKinds[c.operator],
+		Version:  c.version,
+		Original: strings.TrimSpace(c.original),
+	}
+}
+
+// Comparators returns the AST of the constraints, i.e. an OR of AND of comparators.
+// Hyphen ranges are expanded to their comparators.
+// e.g. ">= 1.2, < 2 || 3.0.0 - 3.1.0" => [[>=1.2 <2] [>=3.0.0 <=3.1.0]]
+// Unlike List(), the comparators are exported values which can be inspected and passed to Rewrite.
+func (cs Constraints) Comparators() [][]Comparator {
+	ors := make([][]Comparator, 0, len(cs.constraints))
+	for _, andCs := range cs.constraints {
+		var ands []Comparator
+		for _, c := range andCs {
+			if c.expansion == nil {
+				ands = append(ands, c.comparator())
+				continue
+			}
+			for _, e := range c.expansion {
+				ands = append(ands, e.comparator())
+			}
+		}
+		ors = append(ors, ands)
+	}
+	return ors
+}
+
+// Walk calls fn for each comparator in order until fn returns false.
+func (cs Constraints) Walk(fn func(Comparator) bool) {
+	for _, ands := range cs.Comparators() {
+		for _, c := range ands {
+			if !fn(c) {
+				return
+			}
+		}
+	}
+}
+
+// Rewrite returns new constraints where each comparator is replaced with the comparators returned by fn.
+// fn can return nil to remove the comparator or several comparators to expand it.
+// An OR branch whose comparators are all removed is removed rather than left empty, since an empty
+// branch would be satisfied by every version. If no branch is left, it returns ErrInvalidConstraint.
+// The new constraints are rendered from the comparators with Comparator.String() and parsed
+// with the same options, so String() of the new constraints shows the canonical operators.
+// e.g. replacing "~> 1.2" with ">=1.2" and "<2" renders "~> 1.2 || 3" as ">=1.2,<2||=3"
+func (cs Constraints) Rewrite(fn func(Comparator) []Comparator) (Constraints, error) {
+	css := make([][]Constraint, 0, len(cs.constraints))
+	for _, ands := range cs.Comparators() {
+		var rewritten []Constraint
+		for _, c := range ands {
+			for _, nc := range fn(c) {
+				s := nc.String()
+				parsed, err := newConstraint(s, 0, s)
+				if err != nil {
+					return Constraints{}, xerrors.Errorf("unable to rewrite to %q: %w", s, err)
+				}
+				rewritten = append(rewritten, parsed)
+			}
+		}
+		if len(rewritten) == 0 {
+			continue
+		}
+		css = append(css, rewritten)
+	}
+	if len(css) == 0 {
+		return Constraints{}, xerrors.Errorf("all the comparators are removed: %w", ErrInvalidConstraint)
+	}
+
+	return Constraints{
+		constraints: css,
+		conf:        cs.conf,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints_Comparators(t *testing.T) {
+	type comparator struct {
+		operator Operator
+		version  string
+		original string
+		rendered string
+	}
+	tests := []struct {
+		constraint string
+		want       [][]comparator
+	}{
+		{
+			constraint: ">= 1.2, < 2 || 3.0.0 - 3.1",
+			want: [][]comparator{
+				{
+					{OpGreaterThanOrEqual, "1.2", ">= 1.2", ">=1.2"},
+					{OpLessThan, "2", "< 2", "<2"},
+				},
+				{
+					{OpGreaterThanOrEqual, "3.0.0", ">=3.0.0", ">=3.0.0"},
+					{OpLessThan, "3.2-0", "<3.2-0", "<3.2-0"},
+				},
+			},
+		},
+		{
+			constraint: "=> 1.2.3.4 =<2.3.4-beta.1 != 1.5",
+			want: [][]comparator{
+				{
+					{OpGreaterThanOrEqual, "1.2.3.4", "=> 1.2.3.4", ">=1.2.3.4"},
+					{OpLessThanOrEqual, "2.3.4-beta.1", "=<2.3.4-beta.1", "<=2.3.4-beta.1"},
+					{OpNotEqual, "1.5", "!= 1.5", "!=1.5"},
+				},
+			},
+		},
+		{
+			constraint: "~1.2.3 || ^0.2 || ~> 1.0 || 1.0.0",
+			want: [][]comparator{
+				{{OpTilde, "1.2.3", "~1.2.3", "~1.2.3"}},
+				{{OpCaret, "0.2", "^0.2", "^0.2"}},
+				{{OpPessimistic, "1.0", "~> 1.0", "~>1.0"}},
+				{{OpEqual, "1.0.0", "1.0.0", "=1.0.0"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			var got [][]comparator
+			for _, ands := range c.Comparators() {
+				var cs []comparator
+				for _, a := range ands {
+					cs = append(cs, comparator{a.Operator, a.Version.String(), a.Original, a.String()})
+				}
+				got = append(got, cs)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConstraints_ComparatorsHyphen(t *testing.T) {
+	c, err := NewConstraints("1.2.3 - 2.3.4")
+	require.NoError(t, err)
+
+	got := c.Comparators()
+	require.Len(t, got, 1)
+	require.Len(t, got[0], 2)
+	assert.Equal(t, OpGreaterThanOrEqual, got[0][0].Operator)
+	assert.Equal(t, "1.2.3", got[0][0].Version.String())
+	assert.Equal(t, ">=1.2.3", got[0][0].Original)
+	assert.Equal(t, OpLessThanOrEqual, got[0][1].Operator)
+	assert.Equal(t, "<=2.3.4", got[0][1].Original)
+}
+
+func TestConstraints_Walk(t *testing.T) {
+	c, err := NewConstraints(">=1.2.3 <2 || ~>3.1 || 4.0.0 - 4.2.0")
+	require.NoError(t, err)
+
+	var got []string
+	c.Walk(func(c Comparator) bool {
+		got = append(got, c.String())
+		return true
+	})
+	assert.Equal(t, []string{">=1.2.3", "<2", "~>3.1", ">=4.0.0", "<=4.2.0"}, got)
+
+	// Stop walking
+	got = nil
+	c.Walk(func(c Comparator) bool {
+		got = append(got, c.String())
+		return c.Operator != OpPessimistic
+	})
+	assert.Equal(t, []string{">=1.2.3", "<2", "~>3.1"}, got)
+}
+
+func TestConstraints_Rewrite(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		fn         func(Comparator) []Comparator
+		want       string
+		check      map[string]bool
+	}{
+		{
+			name:       "identity",
+			constraint: "=> 1.2, =<2 || 3.0.0 - 3.1 || 5",
+			fn: func(c Comparator) []Comparator {
+				return []Comparator{c}
+			},
+			want: ">=1.2,<=2||>=3.0.0,<3.2-0||=5",
+			check: map[string]bool{
+				"2.0":   true,
+				"2.0.1": false,
+				"3.1.5": true,
+				"3.2.0": false,
+				"5":     true,
+			},
+		},
+		{
+			name:       "expand pessimistic",
+			constraint: "~> 1.2 || ~> 2.0.1",
+			fn: func(c Comparator) []Comparator {
+				if c.Operator != OpPessimistic {
+					return []Comparator{c}
+				}
+				return []Comparator{
+					{Operator: OpGreaterThanOrEqual, Version: c.Version},
+					{Operator: OpLessThan, Version: c.Version.PessimisticBump()},
+				}
+			},
+			want: ">=1.2,<2.0||>=2.0.1,<2.1.0",
+			check: map[string]bool{
+				"1.9.0": true,
+				"2.0.0": false,
+				"2.0.5": true,
+				"2.1.0": false,
+			},
+		},
+		{
+			name:       "remove",
+			constraint: ">=1.2.3, !=1.5.0 || 3",
+			fn: func(c Comparator) []Comparator {
+				if c.Operator == OpNotEqual {
+					return nil
+				}
+				return []Comparator{c}
+			},
+			want: ">=1.2.3||=3",
+			check: map[string]bool{
+				"1.5.0": true,
+				"1.0.0": false,
+				"3.0":   true,
+			},
+		},
+		{
+			name:       "remove branch",
+			constraint: "<1.0 || >=5.0",
+			fn: func(c Comparator) []Comparator {
+				if c.Operator == OpGreaterThanOrEqual {
+					return nil
+				}
+				return []Comparator{c}
+			},
+			want: "<1.0",
+			check: map[string]bool{
+				"0.9": true,
+				"3.0": false,
+				"5.0": false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			got, err := c.Rewrite(tt.fn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+
+			for s, want := range tt.check {
+				v, err := Parse(s)
+				require.NoError(t, err)
+				assert.Equal(t, want, got.Check(v), s)
+			}
+		})
+	}
+}
+
+func TestConstraints_RewriteRemoveAll(t *testing.T) {
+	c, err := NewConstraints("<1.0 || >=5.0")
+	require.NoError(t, err)
+
+	_, err = c.Rewrite(func(Comparator) []Comparator {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrInvalidConstraint)
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
-
-	"golang.org/x/xerrors"
 )
 
 var (
@@ -24,8 +22,9 @@ var (
 		"~":  constraintTilde,
 		"^":  constraintCaret,
 	}
-	constraintRegexp      *regexp.Regexp
-	validConstraintRegexp *regexp.Regexp
+	constraintRegexp         *regexp.Regexp
+	validConstraintRegexp    *regexp.Regexp
+	anchoredConstraintRegexp *regexp.Regexp
 )
 
 type operatorFunc func(v, c Version) bool
@@ -45,11 +44,14 @@ func init() {
 		`^\s*(\s*(%s)\s*(%s)\s*\,?)*\s*$`,
 		strings.Join(ops, "|"),
 		regex))
+
+	anchoredConstraintRegexp = regexp.MustCompile(`^(?:` + constraintRegexp.String() + `)`)
 }
 
 // Constraints is one or more constraint that a version can be checked against.
 type Constraints struct {
 	constraints [][]Constraint
+	conf        conf
 }
 
 type Constraint struct {
@@ -57,59 +59,160 @@ type Constraint struct {
 	operator     string
 	operatorFunc operatorFunc
 	original     string
+
+	// expansion holds the comparators of a hyphen range such as "1.2.3 - 2.3.4"
+	expansion []Constraint
 }
 
 // NewConstraints parses a given constraint and returns a new instance of Constraints
-func NewConstraints(v string) (Constraints, error) {
+func NewConstraints(v string, opts ...ConstraintOption) (Constraints, error) {
+	c := new(conf)
+
+	// Apply options
+	for _, o := range opts {
+		o.apply(c)
+	}
+
 	var css [][]Constraint
+	var offset int
 	for _, vv := range strings.Split(v, "||") {
+		// Hyphen ranges must be the whole segment
+		if loc := hyphenRegexp.FindStringSubmatchIndex(vv); loc != nil {
+			hc, err := newHyphenConstraint(v, offset, vv, loc)
+			if err != nil {
+				return Constraints{}, err
+			}
+			css = append(css, []Constraint{hc})
+			offset += len(vv) + len("||")
+			continue
+		}
+
 		// Validate the segment
 		if !validConstraintRegexp.MatchString(vv) {
-			return Constraints{}, xerrors.Errorf("improper constraint: %s", vv)
+			return Constraints{}, constraintError(v, offset, vv)
 		}
 
-		ss := constraintRegexp.FindAllString(vv, -1)
-		if ss == nil {
-			ss = append(ss, strings.TrimSpace(vv))
+		locs := constraintRegexp.FindAllStringSubmatchIndex(vv, -1)
+		if err := checkSeparators(v, offset, vv, locs); err != nil {
+			return Constraints{}, err
+		}
+		if locs == nil {
+			// An empty segment
+			locs = [][]int{{len(vv), len(vv)}}
 		}
 
 		var cs []Constraint
-		for _, single := range ss {
-			c, err := newConstraint(single)
+		for _, loc := range locs {
+			sc, err := newConstraint(v, offset+loc[0], vv[loc[0]:loc[1]])
 			if err != nil {
 				return Constraints{}, err
 			}
-			cs = append(cs, c)
+			cs = append(cs, sc)
 		}
 		css = append(css, cs)
+		offset += len(vv) + len("||")
 	}
 
 	return Constraints{
 		constraints: css,
+		conf:        *c,
 	}, nil
 
 }
 
-func newConstraint(c string) (Constraint, error) {
-	m := constraintRegexp.FindStringSubmatch(c)
+// newConstraint parses a comparator c, which starts at offset in input.
+// Errors report the position in input, as the other errors of NewConstraints do.
+func newConstraint(input string, offset int, c string) (Constraint, error) {
+	m := constraintRegexp.FindStringSubmatchIndex(c)
 	if m == nil {
-		return Constraint{}, xerrors.Errorf("improper constraint: %s", c)
+		return Constraint{}, newConstraintError(input, offset, "constraint", "improper constraint", nil)
 	}
+	operator, version := c[m[2]:m[3]], c[m[4]:m[5]]
 
-	v, err := Parse(m[2])
+	v, err := Parse(version)
 	if err != nil {
-		return Constraint{}, xerrors.Errorf("version parse error (%s): %w", m[2], err)
+		return Constraint{}, newConstraintError(input, offset+m[4], "version", "invalid version", err)
 	}
 
 	return Constraint{
 		version:      v,
-		operator:     m[1],
-		operatorFunc: constraintOperators[m[1]],
+		operator:     operator,
+		operatorFunc: constraintOperators[operator],
 		original:     c,
 	}, nil
 }
 
+// checkSeparators rejects a comparator without an operator that is not separated from the previous one
+// by a comma, such as "3" in ">= 1.2 3", since it is ambiguous.
+// Comparators separated by whitespace are ANDed like commas, e.g. ">=1.2.3 <2.0.0".
+// The semver package has the same function since the two packages don't share the constraint parser.
+func checkSeparators(input string, offset int, segment string, locs [][]int) error {
+	for i := 1; i < len(locs); i++ {
+		opStart, opEnd, start := locs[i][2], locs[i][3], locs[i][4]
+		if opStart != opEnd || strings.Contains(segment[locs[i-1][1]:start], ",") {
+			continue
+		}
+		return newConstraintError(input, offset+start, "operator",
+			fmt.Sprintf("missing operator or comma before version %q", segment[start:locs[i][5]]), nil)
+	}
+	return nil
+}
+
+// constraintError locates the first problem in the invalid segment of input
+// starting at offset.
+func constraintError(input string, offset int, segment string) error {
+	i := 0
+	for {
+		i = skipSpace(segment, i)
+		if i == len(segment) {
+			break
+		}
+
+		loc := anchoredConstraintRegexp.FindStringIndex(segment[i:])
+		if loc == nil {
+			return describeConstraintError(input, offset, segment, i)
+		}
+		i = skipSpace(segment, i+loc[1])
+		if i < len(segment) && segment[i] == ',' {
+			i++
+		}
+	}
+	return newConstraintError(input, offset, "constraint", "improper constraint", nil)
+}
+
+// describeConstraintError explains why no constraint could be matched at i.
+func describeConstraintError(input string, offset int, segment string, i int) error {
+	j := i
+	for j < len(segment) && strings.IndexByte("<>=!~^", segment[j]) >= 0 {
+		j++
+	}
+	if op := segment[i:j]; op != "" {
+		if _, ok := constraintOperators[op]; !ok {
+			return newConstraintError(input, offset+i, "operator", fmt.Sprintf("unknown operator %q", op), nil)
+		}
+	}
+
+	j = skipSpace(segment, j)
+	switch {
+	case j == len(segment):
+		return newConstraintError(input, offset+j, "version", "missing version", nil)
+	case strings.IndexByte("v0123456789", segment[j]) < 0:
+		return newConstraintError(input, offset+j, "version", fmt.Sprintf("unexpected character %q", segment[j]), nil)
+	}
+	return newConstraintError(input, offset+j, "version", "invalid version", nil)
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && strings.IndexByte(" \t\n\f\r", s[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
 func (c Constraint) check(v Version) bool {
+	if c.expansion != nil {
+		return andCheck(v, c.expansion)
+	}
 	return c.operatorFunc(v, c.version)
 }
 
@@ -117,14 +220,36 @@ func (c Constraint) String() string {
 	return c.original
 }
 
+// expandedString returns the comparators of a hyphen range separated by a space,
+// e.g. ">=1.2.3 <=2.3.4", or the original string of the other constraints.
+func (c Constraint) expandedString() string {
+	if c.expansion == nil {
+		return c.original
+	}
+
+	s := make([]string, len(c.expansion))
+	for i, e := range c.expansion {
+		s[i] = e.original
+	}
+	return strings.Join(s, " ")
+}
+
+// Version returns the version of the constraint, or the lower bound of a hyphen range.
 func (c Constraint) Version() string {
 	return c.version.String()
 }
 
+// Operator returns the operator of the constraint, or "-" for a hyphen range.
 func (c Constraint) Operator() string {
 	return c.operator
 }
 
+// Expansion returns the comparators which a hyphen range is expanded to,
+// e.g. ">=1.2.3" and "<=2.3.4" for "1.2.3 - 2.3.4". It returns nil for the other constraints.
+func (c Constraint) Expansion() []Constraint {
+	return c.expansion
+}
+
 func (cs Constraints) List() [][]Constraint {
 	return cs.constraints
 }
@@ -140,12 +265,17 @@ func (cs Constraints) Check(v Version) bool {
 	return false
 }
 
-// Returns the string format of the constraints
+// Returns the string format of the constraints.
+// Hyphen ranges are expanded with WithHyphenRangeExpansion(true).
 func (cs Constraints) String() string {
 	var csStr []string
 	for _, orC := range cs.constraints {
 		var cstr []string
 		for _, andC := range orC {
+			if cs.conf.expandHyphenRanges {
+				cstr = append(cstr, andC.expandedString())
+				continue
+			}
 			cstr = append(cstr, andC.String())
 		}
 		csStr = append(csStr, strings.Join(cstr, ","))
@@ -0,0 +1,17 @@
+package version
+
+type conf struct {
+	expandHyphenRanges bool
+}
+
+type ConstraintOption interface {
+	apply(*conf)
+}
+
+// WithHyphenRangeExpansion makes Constraints.String() show hyphen ranges as comparators,
+// e.g. "1.2 - 2.3" => ">=1.2 <2.4-0".
+type WithHyphenRangeExpansion bool
+
+func (o WithHyphenRangeExpansion) apply(c *conf) {
+	c.expandHyphenRanges = bool(o)
+}
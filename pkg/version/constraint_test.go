@@ -2,6 +2,7 @@ package version
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,15 @@ func TestNewConstraints(t *testing.T) {
 		{"2.3.5-20161202202307-sha.e8fc5e5", false},
 		{">= bar", true},
 		{"BAR >= 1.2.3", true},
+
+		// Whitespace separating AND
+		{">= 1.2.3 < 2.0", false},
+		{">= 1.2 3", true},
+
+		// Hyphen ranges
+		{"3 - 4 || => 3.0, < 4", false},
+		{"1.2.3.4 - 2.3.4.5", false},
+		{"1.2.3 - >= 2", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.constraint, func(t *testing.T) {
@@ -37,6 +47,42 @@ func TestNewConstraints(t *testing.T) {
 	}
 }
 
+func TestNewConstraints_Error(t *testing.T) {
+	tests := []struct {
+		constraint string
+		offset     int
+		component  string
+		reason     string
+	}{
+		{"= abc", 2, "version", `unexpected character 'a'`},
+		{"> 1.0 || < foo", 11, "version", `unexpected character 'f'`},
+		{">> 1.0", 0, "operator", `unknown operator ">>"`},
+		{"> 1.0, <", 8, "version", "missing version"},
+		{">= 1.2..3", 6, "version", `unexpected character '.'`},
+		{"1.2.3 - 2.x", 8, "version", "invalid version in hyphen range"},
+		{"> 1 || 1.a - 2", 7, "version", "invalid version in hyphen range"},
+		{"1 - 18446744073709551615", 4, "version", "too large version in hyphen range"},
+		{">= 1.2 3", 7, "operator", `missing operator or comma before version "3"`},
+		{"> 1.0, < 18446744073709551616", 9, "version", "invalid version"},
+		{"1.0 || >=2 <18446744073709551616", 12, "version", "invalid version"},
+		{"1.0 || ", 7, "constraint", "improper constraint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			_, err := NewConstraints(tt.constraint)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidConstraint)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.constraint, perr.Input)
+			assert.Equal(t, tt.offset, perr.Offset)
+			assert.Equal(t, tt.component, perr.Component)
+			assert.Equal(t, tt.reason, perr.Reason)
+		})
+	}
+}
+
 func TestVersion_Check(t *testing.T) {
 	tests := []struct {
 		constraint string
@@ -240,6 +286,8 @@ func TestVersion_Check(t *testing.T) {
 
 		// Build identifiers
 		{">= 1.0.0, < 1.2.0+security-01", "1.0.0", true},
+		{">=1.0.0 <1.2.0", "1.1.0", true},
+		{">=1.0.0 <1.2.0", "1.2.0", false},
 		{">= 1.0.0, < 1.2.0+security-01", "1.2.0", false},
 		{">= 1.0.0, <= 1.2.0+security-01", "1.2.0", true},
 		{">= 1.0.0, < 1.2.0+security-01", "1.3.0", false},
@@ -256,3 +304,61 @@ func TestVersion_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestConstraints_HyphenRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expanded   string
+		version    string
+		want       bool
+	}{
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "1.2.3", true},
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "2.0.0.0", true},
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "2.0.0.1", false},
+		{"1.0.0 - 2.0.0", ">=1.0.0 <=2.0.0", "0.9.9", false},
+		{"1.2.3.4 - 2.3.4.5", ">=1.2.3.4 <=2.3.4.5", "2.3.4.5", true},
+		{"1.2 - 2.3.4", ">=1.2 <=2.3.4", "1.2.0", true},
+		{"1.2 - 2.3.4", ">=1.2 <=2.3.4", "1.1.9", false},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4-0", "2.3.9.9", true},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4-0", "2.4", false},
+		{"1.2.3 - 2.3", ">=1.2.3 <2.4-0", "2.4-rc1", false},
+		{"1.2.3 - 2", ">=1.2.3 <3-0", "2.9.9", true},
+		{"1.2.3 - 2", ">=1.2.3 <3-0", "3.0.0", false},
+		{"1.2.3 - 2", ">=1.2.3 <3-0", "3.0.0-rc1", false},
+		{"1.2.3 - 2-rc1", ">=1.2.3 <=2-rc1", "2.0-beta", true},
+		{"v1.2.3 - v2.3.4", ">=v1.2.3 <=v2.3.4", "2.0.0", true},
+		{"1.2.3 - 2.3.4||>=3", ">=1.2.3 <=2.3.4||>=3", "3.1.0", true},
+		{" 1.2.3   -   2.3.4 ", ">=1.2.3 <=2.3.4", "1.5.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.constraint, tt.version), func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, c.Check(v))
+			assert.Equal(t, strings.TrimSpace(tt.constraint), c.String())
+
+			expanded, err := NewConstraints(tt.constraint, WithHyphenRangeExpansion(true))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expanded, expanded.String())
+
+			// The expansion is equivalent to the hyphen range
+			e, err := NewConstraints(tt.expanded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, e.Check(v))
+		})
+	}
+
+	c, err := NewConstraints("1.2 - 2")
+	require.NoError(t, err)
+	hc := c.List()[0][0]
+	assert.Equal(t, "-", hc.Operator())
+	assert.Equal(t, "1.2", hc.Version())
+	require.Len(t, hc.Expansion(), 2)
+	assert.Equal(t, ">=", hc.Expansion()[0].Operator())
+	assert.Equal(t, "<", hc.Expansion()[1].Operator())
+	assert.Equal(t, "3-0", hc.Expansion()[1].Version())
+}
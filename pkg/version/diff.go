@@ -0,0 +1,126 @@
+package version
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+	"github.com/aquasecurity/go-version/pkg/prerelease"
+)
+
+// DiffKind represents the most significant part changed between two versions.
+type DiffKind int
+
+const (
+	DiffNone DiffKind = iota
+	DiffMetadata
+	DiffPreRelease
+	DiffSegment
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffNone:
+		return "none"
+	case DiffMetadata:
+		return "metadata"
+	case DiffPreRelease:
+		return "prerelease"
+	case DiffSegment:
+		return "segment"
+	}
+	return fmt.Sprintf("DiffKind(%d)", int(k))
+}
+
+// Direction represents whether a change is an upgrade or a downgrade.
+type Direction int
+
+const (
+	Downgrade Direction = iota - 1
+	Unchanged
+	Upgrade
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Downgrade:
+		return "downgrade"
+	case Unchanged:
+		return "unchanged"
+	case Upgrade:
+		return "upgrade"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// Difference represents the change from one version to another.
+type Difference struct {
+	// Kind is the most significant part that differs.
+	Kind DiffKind
+
+	// Segment is the 0-origin index of the first differing segment if Kind is DiffSegment, otherwise -1.
+	// Missing segments are treated as 0, e.g. 1.2 => 1.2.0.1 differs at 3.
+	Segment int
+
+	// Direction is Upgrade if the new version has higher precedence, Downgrade if lower,
+	// and Unchanged otherwise, e.g. only the build metadata differs.
+	Direction Direction
+
+	// Compatible is true if the change is not a downgrade and stays within the caret range
+	// of the old version, i.e. the segments up to the first non-zero one are kept.
+	Compatible bool
+}
+
+// Diff classifies the change from a to b.
+// e.g. 1.2.3.4 => 1.2.4.0 is a compatible upgrade at segment 2, while 0.2.3 => 0.3.0 is an incompatible one at 1.
+// The zero Version is treated as 0.
+func Diff(a, b Version) Difference {
+	a, b = a.withZeroSegment(), b.withZeroSegment()
+	d := Difference{
+		Kind:      DiffNone,
+		Segment:   -1,
+		Direction: Direction(b.Compare(a)),
+	}
+
+	for i := 0; i < len(a.segments) || i < len(b.segments); i++ {
+		if segment(a.segments, i) != segment(b.segments, i) {
+			d.Kind, d.Segment = DiffSegment, i
+			break
+		}
+	}
+	if d.Kind == DiffNone {
+		switch {
+		case prerelease.Compare(a.preRelease, b.preRelease) != 0:
+			d.Kind = DiffPreRelease
+		case a.buildMetadata != b.buildMetadata:
+			d.Kind = DiffMetadata
+		}
+	}
+
+	// The segment that caret ranges keep, like CaretBump
+	significant := len(a.segments) - 1
+	for i, s := range a.segments {
+		if s != 0 {
+			significant = i
+			break
+		}
+	}
+	d.Compatible = d.Direction != Downgrade && (d.Kind != DiffSegment || d.Segment > significant)
+
+	return d
+}
+
+// withZeroSegment returns a copy of the version with a segment 0 if it has none, e.g. the zero Version => 0
+func (v Version) withZeroSegment() Version {
+	if len(v.segments) == 0 {
+		v.segments = []part.Uint64{0}
+	}
+	return v
+}
+
+// segment returns the i-th segment, or 0 if it is missing.
+func segment(segments []part.Uint64, i int) part.Uint64 {
+	if i < len(segments) {
+		return segments[i]
+	}
+	return 0
+}
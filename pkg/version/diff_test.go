@@ -0,0 +1,47 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Difference
+	}{
+		{a: "1.2.3.4", b: "1.2.3.4", want: Difference{Kind: DiffNone, Segment: -1, Direction: Unchanged, Compatible: true}},
+		{a: "1.2", b: "1.2.0.0", want: Difference{Kind: DiffNone, Segment: -1, Direction: Unchanged, Compatible: true}},
+		{a: "1.2.3.4", b: "1.2.4.0", want: Difference{Kind: DiffSegment, Segment: 2, Direction: Upgrade, Compatible: true}},
+		{a: "1.2.3.4", b: "2", want: Difference{Kind: DiffSegment, Segment: 0, Direction: Upgrade}},
+		{a: "1.2", b: "1.2.0.1", want: Difference{Kind: DiffSegment, Segment: 3, Direction: Upgrade, Compatible: true}},
+		{a: "1.2.3", b: "1.1.9", want: Difference{Kind: DiffSegment, Segment: 1, Direction: Downgrade}},
+		{a: "0.2.3", b: "0.2.4", want: Difference{Kind: DiffSegment, Segment: 2, Direction: Upgrade, Compatible: true}},
+		{a: "0.2.3", b: "0.3.0", want: Difference{Kind: DiffSegment, Segment: 1, Direction: Upgrade}},
+		{a: "0.0.0.5", b: "0.0.0.6", want: Difference{Kind: DiffSegment, Segment: 3, Direction: Upgrade}},
+		{a: "0.0", b: "0.0.1", want: Difference{Kind: DiffSegment, Segment: 2, Direction: Upgrade, Compatible: true}},
+		{a: "1.2-beta", b: "1.2", want: Difference{Kind: DiffPreRelease, Segment: -1, Direction: Upgrade, Compatible: true}},
+		{a: "1.2", b: "1.2-beta", want: Difference{Kind: DiffPreRelease, Segment: -1, Direction: Downgrade}},
+		{a: "1.2+a", b: "1.2+b", want: Difference{Kind: DiffMetadata, Segment: -1, Direction: Unchanged, Compatible: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" => "+tt.b, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			require.NoError(t, err)
+			b, err := Parse(tt.b)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, Diff(a, b))
+		})
+	}
+
+	t.Run("zero value", func(t *testing.T) {
+		assert.Equal(t, Difference{Kind: DiffNone, Segment: -1, Direction: Unchanged, Compatible: true}, Diff(Version{}, Version{}))
+
+		v, err := Parse("1.2")
+		require.NoError(t, err)
+		assert.Equal(t, Difference{Kind: DiffSegment, Segment: 0, Direction: Downgrade}, Diff(v, Version{}))
+	})
+}
@@ -0,0 +1,78 @@
+package version
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// ErrInvalidVersion is returned when a given version is invalid
+	ErrInvalidVersion = xerrors.New("malformed version")
+
+	// ErrInvalidConstraint is returned when a given constraint is invalid
+	ErrInvalidConstraint = xerrors.New("improper constraint")
+)
+
+// ParseError describes why a version or constraint string could not be parsed.
+// It matches ErrInvalidVersion or ErrInvalidConstraint with errors.Is.
+type ParseError struct {
+	// Input is the whole string being parsed.
+	Input string
+	// Offset is the byte offset in Input where the problem was found.
+	Offset int
+	// Component is the part of the input being parsed,
+	// e.g. "segment", "prerelease", "buildmetadata", "operator" or "version".
+	Component string
+	// Reason is a human-readable description, e.g. "empty prerelease identifier".
+	Reason string
+	// Err is the underlying error, if any, e.g. *strconv.NumError on overflow.
+	Err error
+
+	kind error
+}
+
+func newVersionError(input string, offset int, component, reason string, err error) *ParseError {
+	return &ParseError{
+		Input:     input,
+		Offset:    offset,
+		Component: component,
+		Reason:    reason,
+		Err:       err,
+		kind:      ErrInvalidVersion,
+	}
+}
+
+func newConstraintError(input string, offset int, component, reason string, err error) *ParseError {
+	return &ParseError{
+		Input:     input,
+		Offset:    offset,
+		Component: component,
+		Reason:    reason,
+		Err:       err,
+		kind:      ErrInvalidConstraint,
+	}
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%s %q: %s at offset %d", e.sentinel(), e.Input, e.Reason, e.Offset)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error and the underlying error, if any.
+func (e *ParseError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.sentinel()}
+	}
+	return []error{e.sentinel(), e.Err}
+}
+
+func (e *ParseError) sentinel() error {
+	if e.kind == nil {
+		return ErrInvalidVersion
+	}
+	return e.kind
+}
@@ -0,0 +1,64 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// e.g. 1.2.3 - 2.3.4
+var hyphenRegexp = regexp.MustCompile(`^\s*(\S+)\s+-\s+(\S+)\s*$`)
+
+// newHyphenConstraint parses a hyphen range such as "1.2.3 - 2.3.4" in segment,
+// which starts at offset in input, and expands it to comparators like npm.
+// An upper bound with less than 3 segments is partial and excludes the next version.
+//
+//	1.2.3 - 2.3.4.5 := >=1.2.3 <=2.3.4.5
+//	1.2 - 2.3.4     := >=1.2 <=2.3.4
+//	1.2.3 - 2.3     := >=1.2.3 <2.4-0
+//	1.2.3 - 2       := >=1.2.3 <3-0
+func newHyphenConstraint(input string, offset int, segment string, loc []int) (Constraint, error) {
+	from, to := segment[loc[2]:loc[3]], segment[loc[4]:loc[5]]
+
+	lower, err := Parse(from)
+	if err != nil {
+		return Constraint{}, newConstraintError(input, offset+loc[2], "version", "invalid version in hyphen range", err)
+	}
+	upper, err := Parse(to)
+	if err != nil {
+		return Constraint{}, newConstraintError(input, offset+loc[4], "version", "invalid version in hyphen range", err)
+	}
+
+	ss := []string{">=" + from}
+	if n := len(upper.segments); n < 3 && upper.preRelease.IsNull() && upper.buildMetadata == "" {
+		last := upper.segments[n-1]
+		if last == ^part.Uint64(0) {
+			return Constraint{}, newConstraintError(input, offset+loc[4], "version", "too large version in hyphen range", nil)
+		}
+		s := make([]string, n)
+		for i, seg := range upper.segments {
+			s[i] = strconv.FormatUint(uint64(seg), 10)
+		}
+		s[n-1] = strconv.FormatUint(uint64(last)+1, 10)
+		ss = append(ss, "<"+strings.Join(s, ".")+"-0")
+	} else {
+		ss = append(ss, "<="+to)
+	}
+
+	c := Constraint{
+		version:  lower,
+		operator: "-",
+		original: strings.TrimSpace(segment),
+	}
+	for _, s := range ss {
+		// The comparators are not a part of input, so an error points at the hyphen range
+		sc, err := newConstraint(s, 0, s)
+		if err != nil {
+			return Constraint{}, newConstraintError(input, offset+loc[2], "version", "invalid hyphen range", err)
+		}
+		c.expansion = append(c.expansion, sc)
+	}
+	return c, nil
+}
@@ -0,0 +1,50 @@
+package version
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Key is a comparable representation of a version which can be used as a map key.
+// Two keys are equal if and only if the versions are equal by Compare.
+type Key struct {
+	canonical string
+}
+
+// String returns the canonical form of the version.
+func (k Key) String() string {
+	return k.canonical
+}
+
+// Canonical returns the version string without trailing zero segments and build metadata,
+// which are ignored by Compare, so that two versions have the same canonical form
+// if and only if Compare returns 0.
+// e.g. 1.2.0 => 1.2, v1.0rc1+build => 1-rc1
+func (v Version) Canonical() string {
+	size := len(v.segments)
+	for size > 1 && v.segments[size-1] == 0 {
+		size--
+	}
+
+	var buf bytes.Buffer
+	for i, s := range v.segments[:size] {
+		if i > 0 {
+			buf.WriteByte('.')
+		}
+		fmt.Fprintf(&buf, "%d", s)
+	}
+	if size == 0 {
+		buf.WriteByte('0')
+	}
+
+	if !v.preRelease.IsNull() {
+		fmt.Fprintf(&buf, "-%s", v.preRelease)
+	}
+	return buf.String()
+}
+
+// Key returns a comparable key of the version.
+// e.g. 1.2, 1.2.0 and 1.2+build have the same key.
+func (v Version) Key() Key {
+	return Key{canonical: v.Canonical()}
+}
@@ -0,0 +1,53 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_Canonical(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.2.3", want: "1.2.3"},
+		{version: "1.2.0", want: "1.2"},
+		{version: "v1.0.0.0", want: "1"},
+		{version: "0.0", want: "0"},
+		{version: "01.02", want: "1.2"},
+		{version: "v1.0rc1+build", want: "1-rc1"},
+		{version: "1.2-beta.01", want: "1.2-beta.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v.Canonical())
+			assert.Equal(t, tt.want, v.Key().String())
+		})
+	}
+}
+
+func TestVersion_Key(t *testing.T) {
+	corpus := []string{
+		"1.2", "1.2.0", "1.2.0.0+build", "v1.2", "1.2.1", "1.2-beta", "1.2.0beta", "1.2-beta.1",
+		"1.2-beta.01", "1.2-beta.1.0", "0", "0.0.0", "1.2.3.4.5", "1.2+a",
+	}
+	versions := make([]Version, len(corpus))
+	for i, s := range corpus {
+		v, err := Parse(s)
+		require.NoError(t, err)
+		versions[i] = v
+	}
+
+	seen := make(map[Key]Version)
+	for _, v1 := range versions {
+		for _, v2 := range versions {
+			assert.Equal(t, v1.Compare(v2) == 0, v1.Key() == v2.Key(), "%s and %s", v1.Original(), v2.Original())
+		}
+		seen[v1.Key()] = v1
+	}
+	assert.Len(t, seen, 7)
+}
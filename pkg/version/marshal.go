@@ -0,0 +1,84 @@
+package version
+
+import (
+	"encoding/json"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/internal/codec"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns String(), so pre-release and build metadata are preserved, but Original() is not.
+// The zero Version is encoded as an empty text.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.text()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// An empty text decodes to the zero Version.
+func (v *Version) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = Version{}
+		return nil
+	}
+
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A version is encoded as a JSON string.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.text())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null leaves the version unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return xerrors.Errorf("version must be a JSON string: %w", err)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Unlike MarshalText, it also preserves Original().
+func (v Version) MarshalBinary() ([]byte, error) {
+	return codec.MarshalBinary(0, v.text(), v.original), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Version) UnmarshalBinary(data []byte) error {
+	flag, ss, err := codec.UnmarshalBinary(data, 2)
+	if err != nil {
+		return err
+	} else if flag != 0 {
+		return xerrors.New("unknown binary version flag")
+	}
+
+	var parsed Version
+	if err = parsed.UnmarshalText([]byte(ss[0])); err != nil {
+		return err
+	}
+	if len(parsed.segments) != 0 {
+		parsed.original = ss[1]
+	}
+	*v = parsed
+	return nil
+}
+
+// text returns String(), or an empty string for the zero Version.
+func (v Version) text() string {
+	if len(v.segments) == 0 {
+		return ""
+	}
+	return v.String()
+}
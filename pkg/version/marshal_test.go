@@ -0,0 +1,128 @@
+package version
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+func TestVersion_MarshalJSON(t *testing.T) {
+	type pkg struct {
+		Name    string   `json:"name"`
+		Version Version  `json:"version"`
+		Fixed   *Version `json:"fixed,omitempty"`
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{
+			name:    "release",
+			version: "1.2.3.4",
+			want:    `{"name":"foo","version":"1.2.3.4"}`,
+		},
+		{
+			name:    "pre-release and metadata",
+			version: "1.2-beta.5+metadata~dist",
+			want:    `{"name":"foo","version":"1.2-beta.5+metadata~dist"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+
+			b, err := json.Marshal(pkg{Name: "foo", Version: v})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(b))
+
+			var got pkg
+			require.NoError(t, json.Unmarshal(b, &got))
+			assert.Equal(t, v, got.Version)
+			assert.Nil(t, got.Fixed)
+		})
+	}
+}
+
+func TestVersion_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{
+			name:  "string",
+			input: `"v1.7rc2"`,
+			want: Version{
+				segments:   []part.Uint64{1, 7},
+				preRelease: part.NewParts("rc2"),
+				original:   "v1.7rc2",
+			},
+		},
+		{name: "null", input: `null`},
+		{name: "empty", input: `""`},
+		{name: "invalid version", input: `"1.2.x"`, wantErr: true},
+		{name: "number", input: `1.2`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Version
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersion_MarshalText(t *testing.T) {
+	v, err := Parse("v1.7rc2+build")
+	require.NoError(t, err)
+
+	b, err := v.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1.7-rc2+build", string(b))
+
+	var got Version
+	require.NoError(t, got.UnmarshalText(b))
+	assert.Equal(t, 0, v.Compare(got))
+	assert.Equal(t, v.buildMetadata, got.buildMetadata)
+	assert.Equal(t, "1.7-rc2+build", got.Original())
+
+	b, err = Version{}.MarshalText()
+	require.NoError(t, err)
+	assert.Empty(t, b)
+}
+
+func TestVersion_MarshalBinary(t *testing.T) {
+	for _, s := range []string{"1", "v1.2.3.4", "1.7rc2", "1.0-", "1.2-.1+a~b", "1.0-x.X"} {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			require.NoError(t, err)
+
+			b, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			var got Version
+			require.NoError(t, got.UnmarshalBinary(b))
+			assert.Equal(t, v, got)
+		})
+	}
+
+	t.Run("broken", func(t *testing.T) {
+		for _, b := range [][]byte{nil, {0}, {1}, {1, 1, 0, 0}, {1, 0, 5, '1'}, {1, 0, 0, 0, 0}} {
+			var got Version
+			assert.Error(t, got.UnmarshalBinary(b), b)
+		}
+	})
+}
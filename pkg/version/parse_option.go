@@ -0,0 +1,43 @@
+package version
+
+type parseConf struct {
+	vPrefix                  bool
+	maxLength                int
+	maxPreReleaseIdentifiers int
+}
+
+func newParseConf(opts []ParseOption) parseConf {
+	c := parseConf{vPrefix: true}
+	for _, o := range opts {
+		o.applyParse(&c)
+	}
+	return c
+}
+
+// ParseOption configures Parse, e.g. WithVPrefix(false).
+type ParseOption interface {
+	applyParse(*parseConf)
+}
+
+// WithVPrefix allows a leading "v" such as "v1.2.3". It is allowed by default.
+type WithVPrefix bool
+
+func (o WithVPrefix) applyParse(c *parseConf) {
+	c.vPrefix = bool(o)
+}
+
+// WithMaxLength rejects versions longer than the given number of bytes
+// before scanning them. Zero means no limit.
+type WithMaxLength int
+
+func (o WithMaxLength) applyParse(c *parseConf) {
+	c.maxLength = int(o)
+}
+
+// WithMaxPreReleaseIdentifiers rejects versions with more than the given number
+// of dot-separated pre-release identifiers. Zero means no limit.
+type WithMaxPreReleaseIdentifiers int
+
+func (o WithMaxPreReleaseIdentifiers) applyParse(c *parseConf) {
+	c.maxPreReleaseIdentifiers = int(o)
+}
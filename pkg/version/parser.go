@@ -0,0 +1,153 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// parse scans a version string by hand. It accepts exactly the language of
+// "^" + regex + "$" and assigns pre-release and build metadata the same way.
+// The options may tighten the language, e.g. WithVPrefix(false) and WithMaxLength.
+func parse(s string, c parseConf) (Version, error) {
+	switch {
+	case s == "":
+		return Version{}, newVersionError(s, 0, "segment", "empty version", nil)
+	case c.maxLength > 0 && len(s) > c.maxLength:
+		return Version{}, newVersionError(s, c.maxLength, "version", fmt.Sprintf("longer than %d bytes", c.maxLength), nil)
+	}
+
+	i := 0
+	if c.vPrefix && s[i] == 'v' {
+		i++
+	}
+
+	// Segments: [0-9]+(\.[0-9]+)*
+	n := 1
+	end := i
+	for end < len(s) && (isDigit(s[end]) || s[end] == '.' && end+1 < len(s) && isDigit(s[end+1])) {
+		if s[end] == '.' {
+			n++
+		}
+		end++
+	}
+	switch {
+	case i == len(s):
+		return Version{}, newVersionError(s, i, "segment", "missing segment", nil)
+	case !isDigit(s[i]):
+		return Version{}, newVersionError(s, i, "segment", fmt.Sprintf("unexpected character %q in segment", s[i]), nil)
+	case end < len(s) && s[end] == '.' && (end+1 == len(s) || s[end+1] == '.'):
+		return Version{}, newVersionError(s, end+1, "segment", "empty segment", nil)
+	case end < len(s) && s[end] == '.':
+		return Version{}, newVersionError(s, end+1, "segment", fmt.Sprintf("unexpected character %q in segment", s[end+1]), nil)
+	}
+	segmentsStart, segmentsEnd := i, end
+	i, component := end, "segment"
+
+	// Pre-release
+	var pre string
+	if i < len(s) && s[i] != '+' {
+		start := i
+		switch {
+		case s[i] == '-' && i+1 < len(s) && isIdentifierChar(s[i+1]):
+			// "-1.2", "-beta.1", "--beta"
+			start = i + 1
+		case s[i] == '-', isLetter(s[i]), s[i] == '~':
+			// "-", "-.1", "beta.1", "~beta"
+		default:
+			return Version{}, newVersionError(s, i, component, fmt.Sprintf("unexpected character %q", s[i]), nil)
+		}
+		var err error
+		if i, err = scanIdentifiers(s, start, "prerelease"); err != nil {
+			return Version{}, err
+		}
+		if n := c.maxPreReleaseIdentifiers; n > 0 {
+			if offset := identifierOffset(s[start:i], n); offset >= 0 {
+				return Version{}, newVersionError(s, start+offset, "prerelease",
+					fmt.Sprintf("more than %d prerelease identifiers", n), nil)
+			}
+		}
+		pre, component = s[start:i], "prerelease"
+	}
+
+	// Build metadata
+	var metadata string
+	if i < len(s) && s[i] == '+' {
+		start := i + 1
+		var err error
+		if i, err = scanIdentifiers(s, start, "buildmetadata"); err != nil {
+			return Version{}, err
+		}
+		metadata, component = s[start:i], "buildmetadata"
+	}
+
+	if i != len(s) {
+		return Version{}, newVersionError(s, i, component, fmt.Sprintf("unexpected character %q", s[i]), nil)
+	}
+
+	segments := make([]part.Uint64, 0, n)
+	start := segmentsStart
+	for j := segmentsStart; j <= segmentsEnd; j++ {
+		if j < segmentsEnd && s[j] != '.' {
+			continue
+		}
+		val, err := strconv.ParseUint(s[start:j], 10, 64)
+		if err != nil {
+			return Version{}, newVersionError(s, start, "segment", "invalid segment", err)
+		}
+		segments = append(segments, part.Uint64(val))
+		start = j + 1
+	}
+
+	return Version{
+		segments:      segments,
+		buildMetadata: metadata,
+		preRelease:    part.NewParts(pre),
+		original:      s,
+	}, nil
+}
+
+// scanIdentifiers scans non-empty dot-separated identifiers starting at i
+// and returns the index just past the last one.
+func scanIdentifiers(s string, i int, component string) (int, error) {
+	for {
+		start := i
+		for i < len(s) && isIdentifierChar(s[i]) {
+			i++
+		}
+		if i == start {
+			return i, newVersionError(s, i, component, fmt.Sprintf("empty %s identifier", component), nil)
+		}
+		if i == len(s) || s[i] != '.' {
+			return i, nil
+		}
+		i++
+	}
+}
+
+// identifierOffset returns the offset of the n-th (0-origin) dot-separated identifier in s,
+// or -1 if s has no more than n identifiers.
+func identifierOffset(s string, n int) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '.' {
+			continue
+		}
+		if n--; n == 0 {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdentifierChar(c byte) bool {
+	return isDigit(c) || isLetter(c) || c == '-' || c == '~'
+}
@@ -0,0 +1,145 @@
+package version
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// referenceRegex is the regular expression Parse used before the hand-written scanner.
+// It is kept here as the reference implementation for the differential tests.
+var referenceRegex = regexp.MustCompile("^" + regex + "$")
+
+func parseWithRegex(v string) (Version, error) {
+	matches := referenceRegex.FindStringSubmatch(v)
+	if matches == nil {
+		return Version{}, xerrors.Errorf("malformed version: %s", v)
+	}
+
+	var segments []part.Uint64
+	for _, str := range strings.Split(matches[1], ".") {
+		val, err := part.NewUint64(str)
+		if err != nil {
+			return Version{}, xerrors.Errorf("error parsing version: %w", err)
+		}
+
+		segments = append(segments, val)
+	}
+
+	pre := matches[7]
+	if pre == "" {
+		pre = matches[4]
+	}
+
+	return Version{
+		segments:      segments,
+		buildMetadata: matches[10],
+		preRelease:    part.NewParts(pre),
+		original:      v,
+	}, nil
+}
+
+var parseCorpus = []string{
+	"",
+	"v",
+	"1",
+	"v1",
+	"vv1",
+	"1.2.3",
+	"1.2.3.4.5.6",
+	"01.002",
+	"1.2.",
+	".1",
+	"v.1",
+	"1..2",
+	"1.2-5",
+	"1.2-beta.5",
+	"1.2--beta",
+	"1.2-~beta",
+	"1.2~beta",
+	"1.2-",
+	"1.2-.1",
+	"1.2-.",
+	"1.2-a..b",
+	"1.7rc2",
+	"1.7rc2.",
+	"1.2.3-rc1-with-hypen",
+	"1.2.0-X-1.2.0+metadata~dist",
+	"1.2+",
+	"1.2+a.b",
+	"1.2+a..b",
+	"1.2-a+b+c",
+	"1.2.x",
+	"\n1.2",
+	"1.2\n",
+	"18446744073709551616",
+	"1.18446744073709551616",
+	"1.2-18446744073709551616",
+	"1.2-ünicode",
+}
+
+func TestParse_Reference(t *testing.T) {
+	for _, tt := range parseCorpus {
+		t.Run(tt, func(t *testing.T) {
+			want, wantErr := parseWithRegex(tt)
+			got, gotErr := Parse(tt)
+			if wantErr != nil {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range parseCorpus {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want, wantErr := parseWithRegex(s)
+		got, gotErr := Parse(s)
+		if (wantErr != nil) != (gotErr != nil) {
+			t.Fatalf("Parse(%q): got error %v, want %v", s, gotErr, wantErr)
+		}
+		if wantErr == nil {
+			assert.Equal(t, want, got)
+		}
+	})
+}
+
+var benchmarkVersions = []string{
+	"1.2.3",
+	"v10.20.30.40-alpha.1+build.5",
+	"1.7rc2",
+	"2.3.5-20161202202307-sha.e8fc5e5",
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkVersions {
+			if _, err := Parse(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkParse_Regex(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkVersions {
+			if _, err := parseWithRegex(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package version
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// zero is the minimum version
+var zero = Version{
+	segments:   []part.Uint64{0},
+	preRelease: part.NewParts("0"),
+	original:   "0-0",
+}
+
+// Bound is the lower or upper end of a Range.
+type Bound struct {
+	version   Version
+	inclusive bool
+
+	// infinity is part.NegativeInfinity or part.Infinity for an open end
+	infinity part.Part
+}
+
+var (
+	negativeInfinity = Bound{infinity: part.NegativeInfinity}
+	infinity         = Bound{infinity: part.Infinity}
+)
+
+// Version returns the version of the bound, or the zero value for an open end.
+func (b Bound) Version() Version {
+	return b.version
+}
+
+// Inclusive returns true if the version of the bound is in the range.
+func (b Bound) Inclusive() bool {
+	return b.inclusive
+}
+
+// IsInfinite returns true if the bound is an open end.
+func (b Bound) IsInfinite() bool {
+	return b.infinity != nil
+}
+
+// String returns "-∞" or "∞" for an open end, otherwise the version.
+func (b Bound) String() string {
+	if b.IsInfinite() {
+		return b.infinity.String()
+	}
+	return b.version.String()
+}
+
+// compare compares the positions of the bounds regardless of inclusiveness.
+func (b Bound) compare(o Bound) int {
+	if b.IsInfinite() || o.IsInfinite() {
+		var p1, p2 part.Part = part.Zero, part.Zero
+		if b.IsInfinite() {
+			p1 = b.infinity
+		}
+		if o.IsInfinite() {
+			p2 = o.infinity
+		}
+		return p1.Compare(p2)
+	}
+	return b.version.Compare(o.version)
+}
+
+// flip returns the bound on the other side of the same version, e.g. "<1.2.3" => ">=1.2.3".
+func (b Bound) flip() Bound {
+	b.inclusive = !b.inclusive
+	return b
+}
+
+// Range is an interval of versions between the lower and upper bounds.
+type Range struct {
+	lower Bound
+	upper Bound
+}
+
+// newRange returns a range between lower and upper, and false if no version is in the range.
+func newRange(lower, upper Bound) (Range, bool) {
+	if !upper.IsInfinite() && !upper.inclusive && upper.version.Compare(zero) == 0 {
+		// <0-0
+		return Range{}, false
+	}
+	c := lower.compare(upper)
+	return Range{lower: lower, upper: upper}, c < 0 || (c == 0 && lower.inclusive && upper.inclusive)
+}
+
+// Lower returns the lower bound.
+func (r Range) Lower() Bound {
+	return r.lower
+}
+
+// Upper returns the upper bound.
+func (r Range) Upper() Bound {
+	return r.upper
+}
+
+// Contains tests if a version is in the range.
+func (r Range) Contains(v Version) bool {
+	b := Bound{version: v}
+	if c := r.lower.compare(b); c > 0 || (c == 0 && !r.lower.inclusive) {
+		return false
+	}
+	c := b.compare(r.upper)
+	return c < 0 || (c == 0 && r.upper.inclusive)
+}
+
+// String returns the range in the form of ">=a <b", or "=a" for a single version.
+// As the version package doesn't support wildcards, all versions are shown as ">=0-0".
+func (r Range) String() string {
+	var ss []string
+	switch {
+	case r.lower.IsInfinite() && r.upper.IsInfinite():
+		return ">=" + zero.String()
+	case r.lower.compare(r.upper) == 0:
+		return "=" + r.lower.String()
+	}
+
+	if !r.lower.IsInfinite() {
+		op := ">"
+		if r.lower.inclusive {
+			op = ">="
+		}
+		ss = append(ss, op+r.lower.String())
+	}
+	if !r.upper.IsInfinite() {
+		op := "<"
+		if r.upper.inclusive {
+			op = "<="
+		}
+		ss = append(ss, op+r.upper.String())
+	}
+	return strings.Join(ss, " ")
+}
+
+// Ranges is a sorted list of disjoint ranges. Adjacent ranges such as "<1.0" and ">=1.0" are merged.
+type Ranges []Range
+
+// Contains tests if a version is in one of the ranges.
+func (rs Ranges) Contains(v Version) bool {
+	for _, r := range rs {
+		if r.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the ranges separated by "||" like Constraints, or "<0-0" if no version is in the ranges.
+func (rs Ranges) String() string {
+	if len(rs) == 0 {
+		return "<" + zero.String()
+	}
+
+	ss := make([]string, len(rs))
+	for i, r := range rs {
+		ss[i] = r.String()
+	}
+	return strings.Join(ss, "||")
+}
+
+// Ranges compiles the constraints into a normalized set of ranges.
+// e.g. "~> 1.2 || ^2.3.4, != 2.5" => ">=1.2 <2||>=2.3.4 <2.5||>2.5 <3.0.0"
+func (cs Constraints) Ranges() Ranges {
+	var rs Ranges
+	for _, andCs := range cs.constraints {
+		rs = append(rs, andRanges(andCs)...)
+	}
+	return normalizeRanges(rs)
+}
+
+func andRanges(constraints []Constraint) Ranges {
+	rs := Ranges{{lower: negativeInfinity, upper: infinity}}
+	for _, c := range constraints {
+		rs = intersectRanges(rs, c.ranges())
+	}
+	return rs
+}
+
+func (c Constraint) ranges() Ranges {
+	if c.expansion != nil {
+		return andRanges(c.expansion)
+	}
+
+	v := Bound{version: c.version, inclusive: true}
+	switch operatorKinds[c.operator] {
+	case OpEqual:
+		return Ranges{{lower: v, upper: v}}
+	case OpNotEqual:
+		return complementRanges(Ranges{{lower: v, upper: v}})
+	case OpGreaterThan:
+		return atLeast(v.flip())
+	case OpGreaterThanOrEqual:
+		return atLeast(v)
+	case OpLessThan:
+		return atMost(v.flip())
+	case OpLessThanOrEqual:
+		return atMost(v)
+	case OpTilde:
+		return intersectRanges(atLeast(v), atMost(Bound{version: c.version.TildeBump()}))
+	case OpCaret:
+		return intersectRanges(atLeast(v), atMost(Bound{version: c.version.CaretBump()}))
+	case OpPessimistic:
+		return intersectRanges(atLeast(v), atMost(Bound{version: c.version.PessimisticBump()}))
+	}
+	return nil
+}
+
+// atLeast returns the versions greater than (or equal to) the lower bound.
+func atLeast(lower Bound) Ranges {
+	if r, ok := newRange(lower, infinity); ok {
+		return Ranges{r}
+	}
+	return nil
+}
+
+// atMost returns the versions less than (or equal to) the upper bound.
+func atMost(upper Bound) Ranges {
+	if r, ok := newRange(negativeInfinity, upper); ok {
+		return Ranges{r}
+	}
+	return nil
+}
+
+func compareLower(a, b Bound) int {
+	if c := a.compare(b); c != 0 {
+		return c
+	}
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return -1
+	}
+	return 1
+}
+
+func compareUpper(a, b Bound) int {
+	if c := a.compare(b); c != 0 {
+		return c
+	}
+	switch {
+	case a.inclusive == b.inclusive:
+		return 0
+	case a.inclusive:
+		return 1
+	}
+	return -1
+}
+
+// normalizeRanges sorts the ranges by the lower bounds and merges overlapping and adjacent ones.
+func normalizeRanges(rs Ranges) Ranges {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(rs)
+	slices.SortStableFunc(sorted, func(a, b Range) int {
+		return compareLower(a.lower, b.lower)
+	})
+
+	merged := Ranges{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if c := r.lower.compare(last.upper); c > 0 || (c == 0 && !r.lower.inclusive && !last.upper.inclusive) {
+			merged = append(merged, r)
+			continue
+		}
+		if compareUpper(r.upper, last.upper) > 0 {
+			last.upper = r.upper
+		}
+	}
+	return merged
+}
+
+// intersectRanges returns the versions in both ranges.
+func intersectRanges(rs1, rs2 Ranges) Ranges {
+	var rs Ranges
+	for _, r1 := range rs1 {
+		for _, r2 := range rs2 {
+			lower, upper := r1.lower, r1.upper
+			if compareLower(r2.lower, lower) > 0 {
+				lower = r2.lower
+			}
+			if compareUpper(r2.upper, upper) < 0 {
+				upper = r2.upper
+			}
+			if r, ok := newRange(lower, upper); ok {
+				rs = append(rs, r)
+			}
+		}
+	}
+	return normalizeRanges(rs)
+}
+
+// complementRanges returns the versions not in the ranges.
+func complementRanges(rs Ranges) Ranges {
+	var complement Ranges
+	lower := negativeInfinity
+	for _, r := range normalizeRanges(rs) {
+		if c, ok := newRange(lower, r.lower.flip()); ok {
+			complement = append(complement, c)
+		}
+		lower = r.upper.flip()
+	}
+	if c, ok := newRange(lower, infinity); ok {
+		complement = append(complement, c)
+	}
+	return complement
+}
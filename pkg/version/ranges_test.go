@@ -0,0 +1,106 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints_Ranges(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{constraint: "1.2.3", want: "=1.2.3"},
+		{constraint: "= 1.2", want: "=1.2"},
+		{constraint: "!=1.2.3", want: "<1.2.3||>1.2.3"},
+		{constraint: ">1.2.3", want: ">1.2.3"},
+		{constraint: ">=1.2.3-beta", want: ">=1.2.3-beta"},
+		{constraint: "<1.2.3.4", want: "<1.2.3.4"},
+		{constraint: "<=1.2", want: "<=1.2"},
+		{constraint: "~1.2.3", want: ">=1.2.3 <1.3.0"},
+		{constraint: "~1.2", want: ">=1.2 <1.3"},
+		{constraint: "^1.2.3", want: ">=1.2.3 <2.0.0"},
+		{constraint: "^0.0.3", want: ">=0.0.3 <0.0.4"},
+		{constraint: "~> 1.2", want: ">=1.2 <2.0"},
+		{constraint: "~> 1.2.3.4", want: ">=1.2.3.4 <1.2.4.0"},
+		{constraint: "< 1.2.0+security-01", want: "<1.2.0+security-01"},
+		{constraint: "<0-0", want: "<0-0"},
+
+		// AND
+		{constraint: ">=1.2.3, <2.0.0", want: ">=1.2.3 <2.0.0"},
+		{constraint: ">=1.2.3 <=1.2.3.0", want: "=1.2.3"},
+		{constraint: ">1.2.3 <1.2.3", want: "<0-0"},
+		{constraint: "^1.2.3, !=1.5", want: ">=1.2.3 <1.5||>1.5 <2.0.0"},
+		{constraint: "1.2.3 - 2.3", want: ">=1.2.3 <2.4-0"},
+
+		// OR
+		{constraint: "~> 1.2 || ^2.3.4, != 2.5", want: ">=1.2 <2.0||>=2.3.4 <2.5||>2.5 <3.0.0"},
+		{constraint: "<1.0 || >=1.0.0", want: ">=0-0"},
+		{constraint: "<1.0 || >1.0", want: "<1.0||>1.0"},
+		{constraint: "^1.0.0 || ^1.5.0", want: ">=1.0.0 <2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Ranges().String())
+
+			// Round trip
+			c, err = NewConstraints(c.Ranges().String())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Ranges().String())
+		})
+	}
+}
+
+func TestConstraints_RangesContains(t *testing.T) {
+	constraints := []string{
+		"1.2.3", "= 1.2", "!=1.2.3", ">1.2.3", ">=1.2.3-beta", "<1.2.3.4", "<=1.2", "~1.2.3", "~1.2",
+		"^1.2.3", "^0.2", "^0.0.3", "~> 1.2", "~> 1.2.3", "~> 1.2.3.4", "~>1.2.3-beta.2",
+		">= 1.0.0, < 1.2.0+security-01", ">=1.2.3, <2.0.0", "^1.2.3, !=1.5", "1.2.3 - 2.3",
+		"~> 1.2 || ^2.3.4, != 2.5", "<1.0 || >1.0",
+	}
+	versions := []string{
+		"0-0", "0", "0.0.3", "0.0.4", "0.2.0", "0.2.9", "0.3", "1.0-alpha", "1.0", "1.0.1", "1.2-beta", "1.2",
+		"1.2.0.0", "1.2.1", "1.2.3-alpha", "1.2.3-beta", "1.2.3-beta.2", "1.2.3-beta.10", "1.2.3", "1.2.3.4",
+		"1.2.3.5", "1.2.4", "1.3-0", "1.3", "1.5", "1.9.9", "2.0-rc.1", "2.0", "2.3.4", "2.4.9", "2.5", "2.6",
+		"3.0.0", "10.0.0",
+	}
+	for _, constraint := range constraints {
+		c, err := NewConstraints(constraint)
+		require.NoError(t, err)
+
+		ranges := c.Ranges()
+		for _, s := range versions {
+			v, err := Parse(s)
+			require.NoError(t, err)
+			assert.Equal(t, c.Check(v), ranges.Contains(v), "%s vs %s (%s)", constraint, s, ranges)
+		}
+	}
+}
+
+func TestRange_Bounds(t *testing.T) {
+	c, err := NewConstraints("<1.2.3 || ^2.0.0")
+	require.NoError(t, err)
+
+	ranges := c.Ranges()
+	require.Len(t, ranges, 2)
+
+	lower, upper := ranges[0].Lower(), ranges[0].Upper()
+	assert.True(t, lower.IsInfinite())
+	assert.Equal(t, "-∞", lower.String())
+	assert.False(t, upper.IsInfinite())
+	assert.False(t, upper.Inclusive())
+	assert.Equal(t, "1.2.3", upper.Version().String())
+
+	lower, upper = ranges[1].Lower(), ranges[1].Upper()
+	assert.True(t, lower.Inclusive())
+	assert.Equal(t, "2.0.0", lower.String())
+	assert.Equal(t, "3.0.0", upper.String())
+
+	c, err = NewConstraints(">=1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "∞", c.Ranges()[0].Upper().String())
+}
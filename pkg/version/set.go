@@ -0,0 +1,97 @@
+package version
+
+// Intersect returns constraints satisfied by versions which satisfy both cs and o.
+// The result is built from Ranges() and has the options of cs,
+// e.g. ">=1.0 <2.0" ∩ ">=1.5 || <0.5" => ">=1.5,<2.0".
+func (cs Constraints) Intersect(o Constraints) Constraints {
+	return newRangeConstraints(intersectRanges(cs.Ranges(), o.Ranges()), cs.conf)
+}
+
+// Union returns constraints satisfied by versions which satisfy cs or o.
+// The result is built from Ranges() and has the options of cs,
+// e.g. "^1.2.3 || ^2.0.0" => ">=1.2.3,<3.0.0".
+func (cs Constraints) Union(o Constraints) Constraints {
+	return newRangeConstraints(append(cs.Ranges(), o.Ranges()...), cs.conf)
+}
+
+// Complement returns constraints satisfied by versions which don't satisfy cs.
+// The result is built from Ranges() and has the options of cs,
+// e.g. "~> 1.2" => "<1.2||>=2.0".
+func (cs Constraints) Complement() Constraints {
+	return newRangeConstraints(complementRanges(cs.Ranges()), cs.conf)
+}
+
+// newComparator returns a constraint such as ">=1.2.3".
+func newComparator(op Operator, v Version) Constraint {
+	return Constraint{
+		version:      v,
+		operator:     op.String(),
+		operatorFunc: constraintOperators[op.String()],
+		original:     op.String() + v.String(),
+	}
+}
+
+// newRangeConstraints returns constraints satisfied by the versions in the ranges.
+// Each range is an AND group, e.g. ">=1.2.3 <2.0.0".
+func newRangeConstraints(rs Ranges, conf conf) Constraints {
+	rs = normalizeRanges(rs)
+	if len(rs) == 0 {
+		// No version is less than 0-0
+		return Constraints{
+			constraints: [][]Constraint{{newComparator(OpLessThan, zero)}},
+			conf:        conf,
+		}
+	}
+
+	var css [][]Constraint
+	for _, r := range rs {
+		css = append(css, r.constraints())
+	}
+	return Constraints{
+		constraints: css,
+		conf:        conf,
+	}
+}
+
+// constraints returns the comparators of the range.
+func (r Range) constraints() []Constraint {
+	switch {
+	case r.lower.IsInfinite() && r.upper.IsInfinite():
+		return []Constraint{newComparator(OpGreaterThanOrEqual, zero)}
+	case r.lower.compare(r.upper) == 0:
+		return []Constraint{newComparator(OpEqual, r.lower.version)}
+	}
+
+	var cs []Constraint
+	if !r.lower.IsInfinite() {
+		op := OpGreaterThan
+		if r.lower.inclusive {
+			op = OpGreaterThanOrEqual
+		}
+		cs = append(cs, newComparator(op, r.lower.version))
+	}
+	if !r.upper.IsInfinite() {
+		op := OpLessThan
+		if r.upper.inclusive {
+			op = OpLessThanOrEqual
+		}
+		cs = append(cs, newComparator(op, r.upper.version))
+	}
+	return cs
+}
+
+// IsSatisfiable returns true if any version satisfies the constraints, e.g. false for ">2.0, <1.0".
+func (cs Constraints) IsSatisfiable() bool {
+	return len(cs.Ranges()) > 0
+}
+
+// Overlaps returns true if any version satisfies both cs and o.
+func (cs Constraints) Overlaps(o Constraints) bool {
+	return len(intersectRanges(cs.Ranges(), o.Ranges())) > 0
+}
+
+// IsSubsetOf returns true if all the versions which satisfy cs also satisfy o,
+// e.g. "~1.2.3" is a subset of "~> 1.2".
+func (cs Constraints) IsSubsetOf(o Constraints) bool {
+	return len(intersectRanges(cs.Ranges(), complementRanges(o.Ranges()))) == 0
+}
@@ -0,0 +1,181 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints_Intersect(t *testing.T) {
+	tests := []struct {
+		c1   string
+		c2   string
+		want string
+	}{
+		{c1: ">=1.0 <2.0", c2: ">=1.5 || <0.5", want: ">=1.5,<2.0"},
+		{c1: "~> 1.2", c2: "!=1.5", want: ">=1.2,<1.5||>1.5,<2.0"},
+		{c1: "1.2.3 - 2.3.4", c2: "^2.0.0", want: ">=2.0.0,<=2.3.4"},
+		{c1: "^1.0.0", c2: "^2.0.0", want: "<0-0"},
+		{c1: ">=1.2.3", c2: "<=1.2.3.0", want: "=1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c1+" ∩ "+tt.c2, func(t *testing.T) {
+			c1, err := NewConstraints(tt.c1)
+			require.NoError(t, err)
+			c2, err := NewConstraints(tt.c2)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.Intersect(c2).String())
+		})
+	}
+}
+
+func TestConstraints_Union(t *testing.T) {
+	tests := []struct {
+		c1   string
+		c2   string
+		want string
+	}{
+		{c1: "^1.2.3", c2: "^2.0.0", want: ">=1.2.3,<3.0.0"},
+		{c1: "<1.0", c2: ">1.0", want: "<1.0||>1.0"},
+		{c1: "<1.0", c2: ">=1.0", want: ">=0-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c1+" ∪ "+tt.c2, func(t *testing.T) {
+			c1, err := NewConstraints(tt.c1)
+			require.NoError(t, err)
+			c2, err := NewConstraints(tt.c2)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.Union(c2).String())
+		})
+	}
+}
+
+func TestConstraints_Complement(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{constraint: "~> 1.2", want: "<1.2||>=2.0"},
+		{constraint: "!=1.2.3", want: "=1.2.3"},
+		{constraint: ">=0-0", want: "<0-0"},
+		{constraint: "<0-0", want: ">=0-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Complement().String())
+		})
+	}
+}
+
+func TestConstraints_SetCheck(t *testing.T) {
+	constraints := []string{
+		"1.2.3", "= 1.2", "!=1.2.3", ">1.2.3", ">=1.2.3-beta", "<1.2.3.4", "<=1.2", "~1.2.3", "^1.2.3",
+		"^0.0.3", "~> 1.2", "~> 1.2.3.4", ">=1.2.3, <2.0.0", "^1.2.3, !=1.5", "1.2.3 - 2.3",
+		"~> 1.2 || ^2.3.4, != 2.5", "<1.0 || >1.0",
+	}
+	versions := []string{
+		"0-0", "0", "0.0.3", "0.0.4", "0.3", "1.0-alpha", "1.0", "1.2-beta", "1.2", "1.2.0.0", "1.2.3-beta",
+		"1.2.3", "1.2.3.4", "1.2.4", "1.3", "1.5", "2.0-rc.1", "2.0", "2.3.4", "2.5", "3.0.0", "10.0.0",
+	}
+	for _, s1 := range constraints {
+		c1, err := NewConstraints(s1)
+		require.NoError(t, err)
+
+		complement := c1.Complement()
+		for _, s2 := range constraints {
+			c2, err := NewConstraints(s2)
+			require.NoError(t, err)
+
+			intersection, union := c1.Intersect(c2), c1.Union(c2)
+			overlaps, subset := c1.Overlaps(c2), c1.IsSubsetOf(c2)
+			for _, s := range versions {
+				v, err := Parse(s)
+				require.NoError(t, err)
+				if c1.Check(v) && c2.Check(v) {
+					assert.True(t, overlaps, "%s overlaps %s with %s", s1, s2, s)
+				}
+				if c1.Check(v) && !c2.Check(v) {
+					assert.False(t, subset, "%s ⊆ %s except %s", s1, s2, s)
+				}
+				assert.Equal(t, c1.Check(v) && c2.Check(v), intersection.Check(v),
+					"%s ∩ %s vs %s (%s)", s1, s2, s, intersection)
+				assert.Equal(t, c1.Check(v) || c2.Check(v), union.Check(v),
+					"%s ∪ %s vs %s (%s)", s1, s2, s, union)
+				assert.Equal(t, !c1.Check(v), complement.Check(v), "¬%s vs %s (%s)", s1, s, complement)
+			}
+		}
+	}
+}
+
+func TestConstraints_IsSatisfiable(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       bool
+	}{
+		{constraint: ">=0-0", want: true},
+		{constraint: "^1.2.3, !=1.5", want: true},
+		{constraint: ">2.0, <1.0", want: false},
+		{constraint: ">1.2.3, <1.2.3.0", want: false},
+		{constraint: ">1.2.3, <=1.2.3.0.1", want: true},
+		{constraint: "<0-0", want: false},
+		{constraint: ">2.0, <1.0 || =1.2.3", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.IsSatisfiable())
+		})
+	}
+}
+
+func TestConstraints_Overlaps(t *testing.T) {
+	tests := []struct {
+		c1   string
+		c2   string
+		want bool
+	}{
+		{c1: "~> 1.2", c2: ">=1.9 <3.0", want: true},
+		{c1: "^1.2.3", c2: "^2.0.0", want: false},
+		{c1: "<=1.2.3", c2: ">=1.2.3.0", want: true},
+		{c1: "<1.2.3", c2: ">=1.2.3", want: false},
+		{c1: "<1.2.3", c2: ">=1.2.3-0", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c1+" ∩ "+tt.c2, func(t *testing.T) {
+			c1, err := NewConstraints(tt.c1)
+			require.NoError(t, err)
+			c2, err := NewConstraints(tt.c2)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.Overlaps(c2))
+			assert.Equal(t, tt.want, c2.Overlaps(c1))
+		})
+	}
+}
+
+func TestConstraints_IsSubsetOf(t *testing.T) {
+	tests := []struct {
+		c1   string
+		c2   string
+		want bool
+	}{
+		{c1: "~1.2.3", c2: "~> 1.2", want: true},
+		{c1: "~> 1.2", c2: "~1.2.3", want: false},
+		{c1: "1.2.3 - 1.4", c2: ">=1.0 <1.4 || ~1.4.0", want: true},
+		{c1: ">2.0, <1.0", c2: "<0-0", want: true},
+		{c1: "^1.0.0", c2: ">=1.0 <2", want: true},
+		{c1: ">=1.0 <=2", c2: "^1.0.0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.c1+" ⊆ "+tt.c2, func(t *testing.T) {
+			c1, err := NewConstraints(tt.c1)
+			require.NoError(t, err)
+			c2, err := NewConstraints(tt.c2)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c1.IsSubsetOf(c2))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package version
+
+import (
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// Simplify returns equivalent constraints built from Ranges(), where redundant comparators and
+// overlapping OR branches are merged, e.g. ">=1.0, >=1.2, <3 || ^1.5" => ">=1.2,<3".
+// Versions are shown without trailing zeros and build metadata, e.g. "<=1.2.0+build" => "<=1.2".
+func (cs Constraints) Simplify() Constraints {
+	return newRangeConstraints(canonicalRanges(cs.Ranges()), cs.conf)
+}
+
+// Canonical returns a string which is identical for constraints satisfied by the same versions,
+// e.g. ">=1.0, >=1.2, <3 || ^1.5" and "~> 1.2 || >=2.0.0, <3" are both ">=1.2,<3".
+// It is the same as Simplify().String().
+func (cs Constraints) Canonical() string {
+	return cs.Simplify().String()
+}
+
+// canonicalRanges returns the ranges whose bounds are canonical versions.
+// "0-0" as an inclusive lower bound is replaced with an open end since it is the minimum version.
+func canonicalRanges(rs Ranges) Ranges {
+	canonical := make(Ranges, 0, len(rs))
+	for _, r := range rs {
+		if !r.lower.IsInfinite() {
+			if r.lower.inclusive && r.lower.version.Compare(zero) == 0 {
+				r.lower = negativeInfinity
+			} else {
+				r.lower.version = canonicalVersion(r.lower.version)
+			}
+		}
+		if !r.upper.IsInfinite() {
+			r.upper.version = canonicalVersion(r.upper.version)
+		}
+		canonical = append(canonical, r)
+	}
+	return canonical
+}
+
+// canonicalVersion returns the version without trailing zeros and build metadata, e.g. 1.2.0+build => 1.2
+func canonicalVersion(v Version) Version {
+	n := len(v.segments)
+	for n > 1 && v.segments[n-1] == 0 {
+		n--
+	}
+
+	c := Version{
+		segments:   make([]part.Uint64, n),
+		preRelease: v.preRelease,
+	}
+	copy(c.segments, v.segments)
+	c.original = c.String()
+	return c
+}
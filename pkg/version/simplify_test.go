@@ -0,0 +1,91 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints_Simplify(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{constraint: ">=1.0, >=1.2, <3 || ^1.5", want: ">=1.2,<3"},
+		{constraint: "~> 1.2 || ^2.3.4, != 2.5", want: ">=1.2,<2||>=2.3.4,<2.5||>2.5,<3"},
+		{constraint: ">=1.2.3, <=1.2.3.0", want: "=1.2.3"},
+		{constraint: "<=1.2.0+build", want: "<=1.2"},
+		{constraint: ">2.0, <1.0", want: "<0-0"},
+		{constraint: "<1.0 || >=1.0", want: ">=0-0"},
+		{constraint: ">=0-0, <1.0", want: "<1"},
+		{constraint: ">0-0, <1.0", want: ">0-0,<1"},
+		{constraint: "1.2.3 - 2.3", want: ">=1.2.3,<2.4-0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := NewConstraints(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Simplify().String())
+		})
+	}
+}
+
+func TestConstraints_Canonical(t *testing.T) {
+	tests := []struct {
+		constraints []string
+		want        string
+	}{
+		{
+			constraints: []string{">=1.0, >=1.2, <3 || ^1.5", "~> 1.2 || >=2.0.0, <3", ">=1.2.0.0 <3.0+build"},
+			want:        ">=1.2,<3",
+		},
+		{
+			constraints: []string{"1.2.3", "=1.2.3.0", ">=1.2.3 <=1.2.3+build"},
+			want:        "=1.2.3",
+		},
+		{
+			constraints: []string{"<2.0 || >=2", ">=0-0", "<1 || >=0.5"},
+			want:        ">=0-0",
+		},
+		{
+			constraints: []string{">2.0, <1.0", "<0-0"},
+			want:        "<0-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			for _, constraint := range tt.constraints {
+				c, err := NewConstraints(constraint)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, c.Canonical(), constraint)
+			}
+		})
+	}
+}
+
+func TestConstraints_SimplifyCheck(t *testing.T) {
+	constraints := []string{
+		"1.2.3", "= 1.2", "!=1.2.3", ">1.2.3", ">=1.2.3-beta", "<1.2.3.4", "<=1.2", "~1.2.3", "^1.2.3",
+		"^0.0.3", "~> 1.2", "~> 1.2.3.4", ">=1.2.3, <2.0.0", "^1.2.3, !=1.5", "1.2.3 - 2.3",
+		"~> 1.2 || ^2.3.4, != 2.5", "<1.0 || >1.0", ">=1.0, >=1.2, <3 || ^1.5",
+	}
+	versions := []string{
+		"0-0", "0", "0.0.3", "0.0.4", "0.3", "1.0-alpha", "1.0", "1.2-beta", "1.2", "1.2.0.0", "1.2.3-beta",
+		"1.2.3", "1.2.3.4", "1.2.4", "1.3", "1.5", "2.0-rc.1", "2.0", "2.3.4", "2.5", "3.0.0", "10.0.0",
+	}
+	for _, s1 := range constraints {
+		c1, err := NewConstraints(s1)
+		require.NoError(t, err)
+
+		simplified := c1.Simplify()
+		parsed, err := NewConstraints(simplified.String())
+		require.NoError(t, err, simplified.String())
+		for _, s := range versions {
+			v, err := Parse(s)
+			require.NoError(t, err)
+			assert.Equal(t, c1.Check(v), simplified.Check(v), "%s vs %s (%s)", s1, s, simplified)
+			assert.Equal(t, c1.Check(v), parsed.Check(v), "%s vs %s (%s)", s1, s, simplified)
+		}
+	}
+}
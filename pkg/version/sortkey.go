@@ -0,0 +1,75 @@
+package version
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+	"github.com/aquasecurity/go-version/pkg/prerelease"
+)
+
+// Tags for segments in sort keys
+const (
+	keySegmentsEnd byte = 0x00
+	keySegment     byte = 0x01
+)
+
+// SortKey returns a byte string whose lexicographic order equals Compare order,
+// so that versions can be stored as keys in key-value stores and range-scanned.
+// Trailing zero segments and build metadata are ignored as well as Compare,
+// e.g. 1.2 and 1.2.0.0 have the same key.
+func (v Version) SortKey() []byte {
+	size := len(v.segments)
+	for size > 0 && v.segments[size-1] == 0 {
+		size--
+	}
+
+	b := make([]byte, 0, 32)
+	for _, s := range v.segments[:size] {
+		b = append(b, keySegment)
+		b = part.AppendUint64Key(b, s)
+	}
+	b = append(b, keySegmentsEnd)
+	return prerelease.AppendSortKey(b, v.preRelease)
+}
+
+// ParseSortKey decodes a key returned by SortKey.
+// Trailing zero segments and build metadata are not restored, and Original() returns String().
+func ParseSortKey(key []byte) (Version, error) {
+	var segments []part.Uint64
+	for {
+		if len(key) == 0 {
+			return Version{}, xerrors.New("invalid sort key: truncated segments")
+		}
+
+		tag := key[0]
+		key = key[1:]
+		if tag == keySegmentsEnd {
+			break
+		} else if tag != keySegment {
+			return Version{}, xerrors.Errorf("invalid sort key: unknown tag 0x%02x", tag)
+		}
+
+		s, rest, err := part.ReadUint64Key(key)
+		if err != nil {
+			return Version{}, xerrors.Errorf("invalid sort key: %w", err)
+		}
+		segments, key = append(segments, s), rest
+	}
+	if len(segments) == 0 {
+		segments = []part.Uint64{0}
+	}
+
+	pre, key, err := prerelease.ReadSortKey(key)
+	if err != nil {
+		return Version{}, xerrors.Errorf("invalid sort key: %w", err)
+	} else if len(key) != 0 {
+		return Version{}, xerrors.New("invalid sort key: trailing data")
+	}
+
+	v := Version{
+		segments:   segments,
+		preRelease: pre,
+	}
+	v.original = v.String()
+	return v, nil
+}
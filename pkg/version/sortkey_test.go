@@ -0,0 +1,82 @@
+package version
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sortKeyVersions = []string{
+	"0",
+	"0.0.0.1",
+	"0.1",
+	"1-alpha",
+	"1.0-alpha.1",
+	"1.0-beta",
+	"1",
+	"1.0.0",
+	"1.0.0.0.1",
+	"1.2-0",
+	"1.2-5",
+	"1.2-rc1",
+	"1.2",
+	"1.2.0.0+build",
+	"1.2.256",
+	"1.7rc2",
+	"1.7",
+	"1.10",
+	"v2.3.1.4",
+	"256",
+}
+
+func TestVersion_SortKey(t *testing.T) {
+	versions := make([]Version, len(sortKeyVersions))
+	for i, s := range sortKeyVersions {
+		v, err := Parse(s)
+		require.NoError(t, err)
+		versions[i] = v
+	}
+
+	for _, v1 := range versions {
+		for _, v2 := range versions {
+			t.Run(fmt.Sprintf("%s vs %s", v1, v2), func(t *testing.T) {
+				assert.Equal(t, v1.Compare(v2), bytes.Compare(v1.SortKey(), v2.SortKey()))
+			})
+		}
+	}
+}
+
+func TestParseSortKey(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"0", "0"},
+		{"0.0.0.1", "0.0.0.1"},
+		{"1.2.0.0+build", "1.2"},
+		{"v1.7rc2", "1.7-rc2"},
+		{"1.0-alpha.1", "1-alpha.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+
+			got, err := ParseSortKey(v.SortKey())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.want, got.Original())
+			assert.Equal(t, 0, v.Compare(got))
+		})
+	}
+
+	t.Run("broken", func(t *testing.T) {
+		for _, key := range [][]byte{nil, {0x01}, {0x02}, {0x00}, {0x00, 0x03, 0x00}} {
+			_, err := ParseSortKey(key)
+			assert.Error(t, err, key)
+		}
+	})
+}
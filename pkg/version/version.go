@@ -3,22 +3,15 @@ package version
 import (
 	"bytes"
 	"fmt"
-	"regexp"
 	"strings"
 
-	"golang.org/x/xerrors"
-
 	"github.com/aquasecurity/go-version/pkg/part"
 	"github.com/aquasecurity/go-version/pkg/prerelease"
 )
 
-// The compiled regular expression used to test the validity of a version.
-var (
-	versionRegex *regexp.Regexp
-)
-
 const (
-	// The raw regular expression string used for testing the validity of a version.
+	// The raw regular expression string matching a version in a constraint.
+	// Parse accepts exactly the same language without using it.
 	regex = `v?([0-9]+(\.[0-9]+)*)` +
 		`(-([0-9]+[0-9A-Za-z\-~]*(\.[0-9A-Za-z\-~]+)*)|(-?([A-Za-z\-~]+[0-9A-Za-z\-~]*(\.[0-9A-Za-z\-~]+)*)))?` +
 		`(\+([0-9A-Za-z\-~]+(\.[0-9A-Za-z\-~]+)*))?`
@@ -32,38 +25,9 @@ type Version struct {
 	original      string
 }
 
-func init() {
-	versionRegex = regexp.MustCompile("^" + regex + "$")
-}
-
 // Parse parses the given version and returns a new Version.
-func Parse(v string) (Version, error) {
-	matches := versionRegex.FindStringSubmatch(v)
-	if matches == nil {
-		return Version{}, xerrors.Errorf("malformed version: %s", v)
-	}
-
-	var segments []part.Uint64
-	for _, str := range strings.Split(matches[1], ".") {
-		val, err := part.NewUint64(str)
-		if err != nil {
-			return Version{}, xerrors.Errorf("error parsing version: %w", err)
-		}
-
-		segments = append(segments, val)
-	}
-
-	pre := matches[7]
-	if pre == "" {
-		pre = matches[4]
-	}
-
-	return Version{
-		segments:      segments,
-		buildMetadata: matches[10],
-		preRelease:    part.NewParts(pre),
-		original:      v,
-	}, nil
+func Parse(v string, opts ...ParseOption) (Version, error) {
+	return parse(v, newParseConf(opts))
 }
 
 // Compare compares this version to another version. This
@@ -88,6 +52,20 @@ func (v Version) Compare(other Version) int {
 	return prerelease.Compare(v.preRelease, other.preRelease)
 }
 
+// CompareStrict compares this version to another version like Compare, but breaks ties
+// by build metadata and then by Original(), so that it returns 0 only if both are
+// literally the same. Use it for deterministic sorting rather than for precedence.
+// e.g. 1.0 < 1.0.0 < 1.0+2 < 1.0+10 < 1.0+build
+func (v Version) CompareStrict(other Version) int {
+	if result := v.Compare(other); result != 0 {
+		return result
+	}
+	if result := part.CompareBuildMetadata(v.buildMetadata, other.buildMetadata); result != 0 {
+		return result
+	}
+	return strings.Compare(v.original, other.original)
+}
+
 // Equal tests if two versions are equal.
 func (v Version) Equal(o Version) bool {
 	return v.Compare(o) == 0
@@ -122,8 +100,8 @@ func (v Version) String() string {
 		fmt.Fprintf(&buf, ".%d", s)
 	}
 
-	if !v.preRelease.IsNull() {
-		fmt.Fprintf(&buf, "-%s", v.preRelease)
+	if len(v.preRelease) > 0 {
+		buf.WriteString("-" + preReleaseString(v.preRelease))
 	}
 	if v.buildMetadata != "" {
 		fmt.Fprintf(&buf, "+%s", v.buildMetadata)
@@ -132,6 +110,19 @@ func (v Version) String() string {
 	return buf.String()
 }
 
+// preReleaseString shows a wildcard in the pre-release as "x" so that the string can be parsed again, e.g. 1.0-x.1
+func preReleaseString(pre part.Parts) string {
+	ss := make([]string, len(pre))
+	for i, p := range pre {
+		if p.IsAny() {
+			ss[i] = "x"
+			continue
+		}
+		ss[i] = p.String()
+	}
+	return strings.Join(ss, ".")
+}
+
 // Original returns the original parsed version as-is, including any
 // potential whitespace, `v` prefix, etc.
 func (v Version) Original() string {
@@ -1,5 +1,7 @@
 package version
 
+import "sort"
+
 // Collection is a type that implements the sort.Interface interface
 // so that versions can be sorted.
 type Collection []Version
@@ -15,3 +17,27 @@ func (v Collection) Less(i, j int) bool {
 func (v Collection) Swap(i, j int) {
 	v[i], v[j] = v[j], v[i]
 }
+
+// StrictCollection is a type that implements the sort.Interface interface
+// so that versions can be sorted deterministically by CompareStrict.
+// Unlike Collection, versions with different build metadata or original strings
+// are never considered equal.
+type StrictCollection []Version
+
+func (v StrictCollection) Len() int {
+	return len(v)
+}
+
+func (v StrictCollection) Less(i, j int) bool {
+	return v[i].CompareStrict(v[j]) < 0
+}
+
+func (v StrictCollection) Swap(i, j int) {
+	v[i], v[j] = v[j], v[i]
+}
+
+// SortStable sorts versions in ascending order by Compare,
+// keeping the original order of versions with the same precedence.
+func SortStable(versions []Version) {
+	sort.Stable(Collection(versions))
+}
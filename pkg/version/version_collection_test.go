@@ -133,3 +133,63 @@ func TestCollection_Constraints(t *testing.T) {
 		})
 	}
 }
+
+func TestStrictCollection(t *testing.T) {
+	versions := []string{
+		"1.0+build.1",
+		"1.0.0",
+		"1.0+10",
+		"1.0-rc1+b",
+		"1.0+build",
+		"1.0",
+		"v1.0",
+		"1.0+2",
+		"1.0-rc1+a",
+	}
+	want := []string{
+		"1.0-rc1+a",
+		"1.0-rc1+b",
+		"1.0",
+		"1.0.0",
+		"v1.0",
+		"1.0+2",
+		"1.0+10",
+		"1.0+build",
+		"1.0+build.1",
+	}
+
+	// Every permutation must be sorted into the same order
+	for _, shift := range []int{0, 4, 8} {
+		vs := make([]Version, len(versions))
+		for i := range versions {
+			v, err := Parse(versions[(i+shift)%len(versions)])
+			require.NoError(t, err)
+			vs[i] = v
+		}
+
+		sort.Sort(StrictCollection(vs))
+
+		got := make([]string, len(vs))
+		for i, v := range vs {
+			got[i] = v.Original()
+		}
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	versions := make([]Version, 0, 5)
+	for _, raw := range []string{"1.0.0", "0.1", "1+build", "1.0", "1.0rc1"} {
+		v, err := Parse(raw)
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+
+	SortStable(versions)
+
+	got := make([]string, len(versions))
+	for i, v := range versions {
+		got[i] = v.Original()
+	}
+	assert.Equal(t, []string{"0.1", "1.0rc1", "1.0.0", "1+build", "1.0"}, got)
+}
@@ -50,6 +50,70 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		version   string
+		offset    int
+		component string
+		reason    string
+	}{
+		{"", 0, "segment", "empty version"},
+		{"v", 1, "segment", "missing segment"},
+		{"foo1.2.3", 0, "segment", `unexpected character 'f' in segment`},
+		{"1.2.", 4, "segment", "empty segment"},
+		{"1..2", 2, "segment", "empty segment"},
+		{"1.2.beta", 4, "segment", `unexpected character 'b' in segment`},
+		{"1.2-beta..1", 9, "prerelease", "empty prerelease identifier"},
+		{"1.2+", 4, "buildmetadata", "empty buildmetadata identifier"},
+		{"1.2-beta_1", 8, "prerelease", `unexpected character '_'`},
+		{"1.2_1", 3, "segment", `unexpected character '_'`},
+		{"18446744073709551616", 0, "segment", "invalid segment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			_, err := Parse(tt.version)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidVersion)
+
+			var perr *ParseError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.version, perr.Input)
+			assert.Equal(t, tt.offset, perr.Offset)
+			assert.Equal(t, tt.component, perr.Component)
+			assert.Equal(t, tt.reason, perr.Reason)
+		})
+	}
+}
+
+func TestParse_Options(t *testing.T) {
+	tests := []struct {
+		version string
+		opts    []ParseOption
+		want    string
+		reason  string
+	}{
+		{version: "v1.2.3", want: "1.2.3"},
+		{version: "v1.2.3", opts: []ParseOption{WithVPrefix(false)}, reason: `unexpected character 'v' in segment`},
+		{version: "1.2.3.4", opts: []ParseOption{WithMaxLength(7)}, want: "1.2.3.4"},
+		{version: "1.2.3.4", opts: []ParseOption{WithMaxLength(6)}, reason: "longer than 6 bytes"},
+		{version: "1.2-a.b", opts: []ParseOption{WithMaxPreReleaseIdentifiers(2)}, want: "1.2-a.b"},
+		{version: "1.2-a.b.c", opts: []ParseOption{WithMaxPreReleaseIdentifiers(2)}, reason: "more than 2 prerelease identifiers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := Parse(tt.version, tt.opts...)
+			if tt.reason != "" {
+				var perr *ParseError
+				require.ErrorAs(t, err, &perr)
+				assert.Equal(t, tt.reason, perr.Reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
 func TestVersion_Compare(t *testing.T) {
 	tests := []struct {
 		v1, v2 string
@@ -130,6 +194,7 @@ func TestVersion_String(t *testing.T) {
 		{"1.2-beta", "1.2-beta"},
 		{"1.2.0-metadata-1.2.0+metadata~dist", "1.2.0-metadata-1.2.0+metadata~dist"},
 		{"17.03.0-ce", "17.3.0-ce"},
+		{"1.0-x.X.1", "1.0-x.x.1"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.version, func(t *testing.T) {
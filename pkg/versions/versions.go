@@ -0,0 +1,75 @@
+package versions
+
+import (
+	"slices"
+)
+
+// Comparable is the contract shared by versions of any scheme,
+// such as semver.Version and version.Version.
+type Comparable[T any] interface {
+	// Compare returns -1, 0, or 1 if the version is smaller, equal, or larger than the other version.
+	Compare(T) int
+	String() string
+	Original() string
+}
+
+// Sort sorts versions in ascending order, keeping the original order of equal versions.
+func Sort[T Comparable[T]](vs []T) {
+	slices.SortStableFunc(vs, compare[T])
+}
+
+// Max returns the maximum version. If there are several maximum versions, it returns the first one.
+// It returns false if vs is empty.
+func Max[T Comparable[T]](vs []T) (T, bool) {
+	var maxVersion T
+	if len(vs) == 0 {
+		return maxVersion, false
+	}
+
+	maxVersion = vs[0]
+	for _, v := range vs[1:] {
+		if v.Compare(maxVersion) > 0 {
+			maxVersion = v
+		}
+	}
+	return maxVersion, true
+}
+
+// Min returns the minimum version. If there are several minimum versions, it returns the first one.
+// It returns false if vs is empty.
+func Min[T Comparable[T]](vs []T) (T, bool) {
+	var minVersion T
+	if len(vs) == 0 {
+		return minVersion, false
+	}
+
+	minVersion = vs[0]
+	for _, v := range vs[1:] {
+		if v.Compare(minVersion) < 0 {
+			minVersion = v
+		}
+	}
+	return minVersion, true
+}
+
+// Filter returns a new slice of the versions for which keep returns true.
+// e.g. Filter(vs, constraints.Check)
+func Filter[T Comparable[T]](vs []T, keep func(T) bool) []T {
+	var filtered []T
+	for _, v := range vs {
+		if keep(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// BinarySearch searches for target in versions sorted by Sort and returns the position
+// where target is found, or where it would be inserted, and whether it is found.
+func BinarySearch[T Comparable[T]](sorted []T, target T) (int, bool) {
+	return slices.BinarySearchFunc(sorted, target, compare[T])
+}
+
+func compare[T Comparable[T]](a, b T) int {
+	return a.Compare(b)
+}
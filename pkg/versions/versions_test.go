@@ -0,0 +1,127 @@
+package versions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-version/pkg/semver"
+	"github.com/aquasecurity/go-version/pkg/version"
+	"github.com/aquasecurity/go-version/pkg/versions"
+)
+
+var (
+	_ versions.Comparable[semver.Version]  = semver.Version{}
+	_ versions.Comparable[version.Version] = version.Version{}
+)
+
+func parseSemVer(t *testing.T, raw ...string) []semver.Version {
+	vs := make([]semver.Version, len(raw))
+	for i, s := range raw {
+		v, err := semver.Parse(s)
+		require.NoError(t, err)
+		vs[i] = v
+	}
+	return vs
+}
+
+func parseVersion(t *testing.T, raw ...string) []version.Version {
+	vs := make([]version.Version, len(raw))
+	for i, s := range raw {
+		v, err := version.Parse(s)
+		require.NoError(t, err)
+		vs[i] = v
+	}
+	return vs
+}
+
+func originals[T versions.Comparable[T]](vs []T) []string {
+	s := make([]string, len(vs))
+	for i, v := range vs {
+		s[i] = v.Original()
+	}
+	return s
+}
+
+func TestSort(t *testing.T) {
+	t.Run("semver", func(t *testing.T) {
+		vs := parseSemVer(t, "1.1.0", "1.0.0+b", "1.0.0-rc.1", "1.0.0+a", "0.7.1")
+		versions.Sort(vs)
+		assert.Equal(t, []string{"0.7.1", "1.0.0-rc.1", "1.0.0+b", "1.0.0+a", "1.1.0"}, originals(vs))
+	})
+	t.Run("version", func(t *testing.T) {
+		vs := parseVersion(t, "1.2.0", "1.10", "1.2", "1.2rc1", "0.9.9.9")
+		versions.Sort(vs)
+		assert.Equal(t, []string{"0.9.9.9", "1.2rc1", "1.2.0", "1.2", "1.10"}, originals(vs))
+	})
+}
+
+func TestMax(t *testing.T) {
+	got, ok := versions.Max(parseSemVer(t, "1.0.0", "2.0.0-rc.1", "1.9.9", "2.0.0-beta"))
+	require.True(t, ok)
+	assert.Equal(t, "2.0.0-rc.1", got.Original())
+
+	got2, ok := versions.Max(parseVersion(t, "1.2", "1.2.0.0", "1.1.9"))
+	require.True(t, ok)
+	assert.Equal(t, "1.2", got2.Original())
+
+	_, ok = versions.Max([]semver.Version{})
+	assert.False(t, ok)
+}
+
+func TestMin(t *testing.T) {
+	got, ok := versions.Min(parseSemVer(t, "1.0.0", "1.0.0-rc.1", "1.9.9"))
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0-rc.1", got.Original())
+
+	got2, ok := versions.Min(parseVersion(t, "1.2", "1.2.0.0", "1.3"))
+	require.True(t, ok)
+	assert.Equal(t, "1.2", got2.Original())
+
+	_, ok = versions.Min[version.Version](nil)
+	assert.False(t, ok)
+}
+
+func TestFilter(t *testing.T) {
+	cs, err := semver.NewConstraints(">=1.2.0 <2.0.0")
+	require.NoError(t, err)
+
+	got := versions.Filter(parseSemVer(t, "1.1.0", "1.2.0", "1.5.3", "2.0.0"), cs.Check)
+	assert.Equal(t, []string{"1.2.0", "1.5.3"}, originals(got))
+
+	// The max satisfying version
+	vcs, err := version.NewConstraints("~> 1.2")
+	require.NoError(t, err)
+
+	got3, ok := versions.Max(versions.Filter(parseVersion(t, "1.1", "1.2.5", "1.9.0.1", "2.0"), vcs.Check))
+	require.True(t, ok)
+	assert.Equal(t, "1.9.0.1", got3.Original())
+}
+
+func TestBinarySearch(t *testing.T) {
+	vs := parseSemVer(t, "0.1.0", "1.0.0-rc.1", "1.0.0", "1.2.0", "2.0.0")
+
+	tests := []struct {
+		target    string
+		wantIndex int
+		wantFound bool
+	}{
+		{target: "0.0.1", wantIndex: 0},
+		{target: "1.0.0", wantIndex: 2, wantFound: true},
+		{target: "1.0.0+build", wantIndex: 2, wantFound: true},
+		{target: "1.0.0-beta", wantIndex: 1},
+		{target: "1.1.0", wantIndex: 3},
+		{target: "3.0.0", wantIndex: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			target, err := semver.Parse(tt.target)
+			require.NoError(t, err)
+
+			i, found := versions.BinarySearch(vs, target)
+			assert.Equal(t, tt.wantIndex, i)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}